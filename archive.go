@@ -0,0 +1,96 @@
+package cuebridge
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ValidateTar validates every JSON, JSONC, YAML, and TOML entry of a tar archive
+// (.tar, or gzip-compressed such as .tar.gz, detected from its content)
+// in archive order, detecting each entry's format from its extension.
+// Each result is named by the entry's path in the archive. Directories,
+// links, and entries with other extensions are skipped.
+//
+// definitionFor, if not nil, returns the definition to check each entry
+// against given its path (e.g., "#Service" for "services/api.yaml");
+// an empty name, like a nil definitionFor, uses the validator's definition.
+//
+// Returns an error if the archive cannot be read or an entry cannot be
+// validated (e.g., definitionFor returns a definition that does not exist).
+func (v *Validator) ValidateTar(archivePath string, definitionFor func(name string) string) ([]ValidationResult, error) {
+	if err := v.checkFileAccess(archivePath); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive %s: %w", archivePath, err)
+	}
+	defer file.Close()
+
+	archive, err := openTar(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive %s: %w", archivePath, err)
+	}
+
+	var results []ValidationResult
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive %s: %w", archivePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		format, ok := formatFromExtension(header.Name)
+		if !ok {
+			continue
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from archive %s: %w", header.Name, archivePath, err)
+		}
+
+		input := ValidationInput{
+			SourceType: SourceBytes,
+			Data:       data,
+			Format:     format,
+			Name:       header.Name,
+		}
+		if definitionFor != nil {
+			input.Definition = definitionFor(header.Name)
+		}
+		result, err := v.validate(input)
+		if err != nil {
+			return nil, fmt.Errorf("validating %s: %w", header.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// openTar returns a tar reader for r, decompressing it first if it starts
+// with the gzip magic number
+func openTar(r io.Reader) (*tar.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		decompressed, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return tar.NewReader(decompressed), nil
+	}
+	return tar.NewReader(buffered), nil
+}
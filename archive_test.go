@@ -0,0 +1,112 @@
+package cuebridge
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTar writes a tar archive with the given entries, gzip-compressed if
+// compress is set. Entry names ending in "/" are directories.
+func writeTar(t *testing.T, path string, entries [][2]string, compress bool) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	var w io.Writer = file
+	if compress {
+		gz := gzip.NewWriter(file)
+		defer gz.Close()
+		w = gz
+	}
+	archive := tar.NewWriter(w)
+	defer archive.Close()
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry[0], Mode: 0644, Size: int64(len(entry[1])), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(entry[0], "/") {
+			header = &tar.Header{Name: entry[0], Mode: 0755, Typeflag: tar.TypeDir}
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := archive.Write([]byte(entry[1])); err != nil {
+			t.Fatalf("failed to write entry: %v", err)
+		}
+	}
+}
+
+// TestValidateTar tests validating the config entries of tar archives
+func TestValidateTar(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}
+#Service: {port: int}`)
+
+	entries := [][2]string{
+		{"bundle/", ""},
+		{"bundle/app.yaml", "name: app\n"},
+		{"bundle/README.md", "# not a config\n"},
+		{"bundle/bad.json", `{"name": 1}`},
+		{"bundle/services/api.toml", "port = 8080\n"},
+	}
+	definitionFor := func(name string) string {
+		if strings.HasPrefix(name, "bundle/services/") {
+			return "#Service"
+		}
+		return ""
+	}
+
+	for _, tt := range []struct {
+		name     string
+		compress bool
+	}{
+		{name: "bundle.tar"},
+		{name: "bundle.tar.gz", compress: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			writeTar(t, path, entries, tt.compress)
+
+			results, err := validator.ValidateTar(path, definitionFor)
+			if err != nil {
+				t.Fatalf("ValidateTar failed: %v", err)
+			}
+
+			want := []struct {
+				name       string
+				valid      bool
+				definition string
+			}{
+				{"bundle/app.yaml", true, "#Config"},
+				{"bundle/bad.json", false, "#Config"},
+				{"bundle/services/api.toml", true, "#Service"},
+			}
+			if len(results) != len(want) {
+				t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+			}
+			for i, w := range want {
+				if results[i].Name != w.name || results[i].Valid != w.valid || results[i].Definition != w.definition {
+					t.Errorf("result %d = %s valid=%v definition=%s, want %s valid=%v definition=%s",
+						i, results[i].Name, results[i].Valid, results[i].Definition, w.name, w.valid, w.definition)
+				}
+			}
+		})
+	}
+
+	t.Run("not an archive", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "broken.tar.gz")
+		if err := os.WriteFile(path, []byte{0x1f, 0x8b, 0x00}, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if _, err := validator.ValidateTar(path, nil); err == nil {
+			t.Error("expected an error for a broken archive")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// findDeprecatedFields reports a warning for each field present in data
+// whose schema field carries a @deprecated attribute
+func findDeprecatedFields(unified cue.Value, data cue.Value) []ValidationError {
+	var warnings []ValidationError
+	walkDataFields(unified, data, nil, func(path []string, schemaField, dataField cue.Value) {
+		attr := schemaField.Attribute("deprecated")
+		if attr.Err() != nil {
+			return
+		}
+
+		message := "field is deprecated"
+		if reason, err := attr.String(0); err == nil && reason != "" {
+			message = fmt.Sprintf("field is deprecated: %s", reason)
+		}
+
+		warnings = append(warnings, ValidationError{
+			Line:     dataField.Pos().Line(),
+			Column:   dataField.Pos().Column(),
+			Path:     formatPath(path),
+			Pointer:  jsonPointer(path),
+			Message:  message,
+			Severity: SeverityWarning,
+		})
+	})
+	return warnings
+}
+
+// applyMessageAttributes replaces the message of each error whose schema
+// field carries a @msg attribute (e.g., @msg("port must be between 1 and
+// 65535")) with the attribute text. errs must have been extracted from err.
+func applyMessageAttributes(unified cue.Value, err error, errs []ValidationError) {
+	cueErrors := errors.Errors(err)
+	if len(cueErrors) != len(errs) {
+		return
+	}
+
+	for i, e := range cueErrors {
+		if len(e.Path()) == 0 {
+			continue
+		}
+		if message, ok := messageAttribute(unified.LookupPath(inputPath(e.Path()))); ok {
+			errs[i].Message = message
+		}
+	}
+}
+
+// messageAttribute returns the text of a field's @msg attribute, if any
+func messageAttribute(field cue.Value) (string, bool) {
+	attr := field.Attribute("msg")
+	if attr.Err() != nil {
+		return "", false
+	}
+	message, err := attr.String(0)
+	return message, err == nil && message != ""
+}
+
+// findMissingRecommended reports a warning for each schema field carrying a
+// @recommended attribute that is absent from data, within the structs
+// present in data
+func findMissingRecommended(unified cue.Value, data cue.Value) []ValidationError {
+	var warnings []ValidationError
+	walkMissingRecommended(unified, data, nil, &warnings)
+	return warnings
+}
+
+// walkMissingRecommended checks the fields of unified against data, recursing
+// into the structs and lists present in data
+func walkMissingRecommended(unified cue.Value, data cue.Value, path []string, warnings *[]ValidationError) {
+	switch data.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := unified.Fields(cue.Optional(true))
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			sel := cue.Str(iter.Selector().Unquoted())
+			fieldPath := append(path[:len(path):len(path)], sel.String())
+			dataField := data.LookupPath(cue.MakePath(sel))
+			if dataField.Exists() {
+				walkMissingRecommended(iter.Value(), dataField, fieldPath, warnings)
+				continue
+			}
+
+			attr := iter.Value().Attribute("recommended")
+			if attr.Err() != nil {
+				continue
+			}
+			message := "recommended field is missing"
+			if reason, err := attr.String(0); err == nil && reason != "" {
+				message = fmt.Sprintf("recommended field is missing: %s", reason)
+			}
+			*warnings = append(*warnings, ValidationError{
+				Line:     data.Pos().Line(),
+				Column:   data.Pos().Column(),
+				Path:     formatPath(fieldPath),
+				Pointer:  jsonPointer(fieldPath),
+				Message:  message,
+				Severity: SeverityWarning,
+			})
+		}
+	case cue.ListKind:
+		iter, err := data.List()
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			sel := iter.Selector()
+			elemPath := append(path[:len(path):len(path)], sel.String())
+			walkMissingRecommended(unified.LookupPath(cue.MakePath(sel)), iter.Value(), elemPath, warnings)
+		}
+	}
+}
+
+// withoutAbstractIncomplete removes errors inside fields carrying an @abstract
+// attribute, which need not be concrete, keeping the errors such fields
+// have when validated non-concretely (e.g., conflicting values).
+// Returns nil if no errors remain.
+func withoutAbstractIncomplete(unified cue.Value, err error) error {
+	var remaining errors.Error
+	checked := map[string]bool{}
+	for _, e := range errors.Errors(err) {
+		root, ok := abstractRoot(unified, e.Path())
+		if !ok {
+			remaining = errors.Append(remaining, e)
+			continue
+		}
+		if checked[root.String()] {
+			continue
+		}
+		checked[root.String()] = true
+		if abstractErr := unified.LookupPath(root).Validate(); abstractErr != nil {
+			remaining = errors.Append(remaining, errors.Promote(abstractErr, ""))
+		}
+	}
+	if remaining == nil {
+		return nil
+	}
+	return remaining
+}
+
+// abstractRoot returns the path of the outermost field on an error path
+// that carries an @abstract attribute
+func abstractRoot(unified cue.Value, errPath []string) (cue.Path, bool) {
+	for i := 1; i <= len(errPath); i++ {
+		path := inputPath(errPath[:i])
+		if len(path.Selectors()) == 0 {
+			continue
+		}
+		attr := unified.LookupPath(path).Attribute("abstract")
+		if attr.Err() == nil {
+			return path, true
+		}
+	}
+	return cue.Path{}, false
+}
+
+// walkDataFields calls fn for every field present in data, recursing into
+// structs and lists, along with the corresponding field of the unified value
+func walkDataFields(unified cue.Value, data cue.Value, path []string, fn func(path []string, schemaField, dataField cue.Value)) {
+	switch data.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := data.Fields()
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			sel := iter.Selector()
+			fieldPath := append(path[:len(path):len(path)], sel.String())
+			schemaField := unified.LookupPath(cue.MakePath(sel))
+			fn(fieldPath, schemaField, iter.Value())
+			walkDataFields(schemaField, iter.Value(), fieldPath, fn)
+		}
+	case cue.ListKind:
+		iter, err := data.List()
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			sel := iter.Selector()
+			elemPath := append(path[:len(path):len(path)], sel.String())
+			walkDataFields(unified.LookupPath(cue.MakePath(sel)), iter.Value(), elemPath, fn)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestReportDeprecated tests warnings for fields annotated with @deprecated
+func TestReportDeprecated(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	schema := `#Config: {
+	name:      string
+	replicas?: int @deprecated("use scaling.min")
+	scaling?: {min?: int, legacy?: bool @deprecated()}
+}`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	validator, err := NewValidatorWithOptions(schemaPath, "#Config", ValidationOptions{ReportDeprecated: true})
+	if err != nil {
+		t.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nreplicas: 2\nscaling:\n  legacy: true\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if !result.Valid {
+		t.Fatalf("deprecated fields should not fail validation: %v", result.Errors)
+	}
+
+	want := map[string]string{
+		"replicas":       "field is deprecated: use scaling.min",
+		"scaling.legacy": "field is deprecated",
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %v", len(result.Errors), len(want), result.Errors)
+	}
+	for _, e := range result.Errors {
+		if e.Severity != SeverityWarning {
+			t.Errorf("%s: Severity = %v, want warning", e.Path, e.Severity)
+		}
+		if want[e.Path] != e.Message {
+			t.Errorf("%s: Message = %q, want %q", e.Path, e.Message, want[e.Path])
+		}
+		if e.Line == 0 {
+			t.Errorf("%s: Line should point into the input", e.Path)
+		}
+	}
+}
+
+// TestReportRecommended tests warnings for missing fields annotated with @recommended
+func TestReportRecommended(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	name!:  string
+	owner?: string @recommended("set an owner for paging")
+	spec: {
+		replicas:   int
+		resources?: {cpu?: string} @recommended()
+	}
+	ports?: [...{port: int, name?: string @recommended()}]
+}`, ValidationOptions{ReportRecommended: true})
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPaths []string
+	}{
+		{
+			name:      "all recommended present",
+			data:      "name: app\nowner: team-a\nspec:\n  replicas: 2\n  resources: {cpu: 500m}\n",
+			wantValid: true,
+		},
+		{
+			name:      "recommended missing",
+			data:      "name: app\nspec:\n  replicas: 2\nports:\n  - port: 80\n    name: http\n  - port: 443\n",
+			wantValid: true,
+			wantPaths: []string{"owner", "spec.resources", "ports.1.name"},
+		},
+		{
+			name:      "required missing",
+			data:      "owner: team-a\nspec:\n  replicas: 2\n  resources: {}\n",
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatYAML,
+				Name:       "config.yaml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+
+			var gotPaths []string
+			for _, e := range result.Errors {
+				if e.Severity == SeverityWarning {
+					gotPaths = append(gotPaths, e.Path)
+				}
+			}
+			if !slices.Equal(gotPaths, tt.wantPaths) {
+				t.Errorf("warnings at %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+// TestAbstractFields tests exempting @abstract fields from the concrete requirement
+func TestAbstractFields(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name: string
+	port: int
+	computed: {
+		hash: string
+		size: int & >=0
+	} @abstract()
+	tags: [...{key: string, value: string @abstract()}]
+}`)
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPaths []string
+	}{
+		{
+			name:      "abstract fields left open",
+			data:      `{"name": "app", "port": 80, "tags": [{"key": "team"}]}`,
+			wantValid: true,
+		},
+		{
+			name:      "abstract fields given",
+			data:      `{"name": "app", "port": 80, "computed": {"hash": "abc", "size": 3}}`,
+			wantValid: true,
+		},
+		{
+			name:      "concrete field missing",
+			data:      `{"name": "app", "computed": {}}`,
+			wantValid: false,
+			wantPaths: []string{"port"},
+		},
+		{
+			name:      "conflict inside abstract field",
+			data:      `{"name": "app", "port": 80, "computed": {"size": -1}}`,
+			wantValid: false,
+			wantPaths: []string{"computed.size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+
+			var gotPaths []string
+			for _, e := range result.Errors {
+				gotPaths = append(gotPaths, e.Path)
+			}
+			if !slices.Equal(gotPaths, tt.wantPaths) {
+				t.Errorf("errors at %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+// TestMessageAttributes tests replacing CUE messages with @msg text
+func TestMessageAttributes(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	port: int & >0 & <65536 @msg("Port must be between 1 and 65535")
+	name: string
+	containers: [...{image: =~":" @msg("Image must include a tag")}]
+}`)
+
+	tests := []struct {
+		name        string
+		data        string
+		wantPath    string
+		wantMessage string
+	}{
+		{
+			name:        "custom message",
+			data:        `{"port": 0, "name": "app", "containers": []}`,
+			wantPath:    "port",
+			wantMessage: "Port must be between 1 and 65535",
+		},
+		{
+			name:        "nested in list",
+			data:        `{"port": 80, "name": "app", "containers": [{"image": "nginx"}]}`,
+			wantPath:    "containers.0.image",
+			wantMessage: "Image must include a tag",
+		},
+		{
+			name:        "falls back to CUE message",
+			data:        `{"port": 80, "name": 1, "containers": []}`,
+			wantPath:    "name",
+			wantMessage: "conflicting values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid || len(result.Errors) != 1 {
+				t.Fatalf("expected a single error, got %v", result.Errors)
+			}
+			e := result.Errors[0]
+			if e.Path != tt.wantPath || !strings.Contains(e.Message, tt.wantMessage) {
+				t.Errorf("error = %q at %q, want %q at %q", e.Message, e.Path, tt.wantMessage, tt.wantPath)
+			}
+		})
+	}
+}
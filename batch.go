@@ -0,0 +1,254 @@
+package cuebridge
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ValidateMap validates a set of inputs keyed by caller-chosen identifiers
+// (e.g., request IDs) and returns the results under the same keys.
+//
+// If an input's Name is empty, it defaults to its map key. Inputs are
+// validated in order of their keys, so when several fail to be read, the
+// error reports the first key.
+// Returns an error only if the validation process itself fails for any input.
+func (v *Validator) ValidateMap(inputs map[string]ValidationInput) (map[string]ValidationResult, error) {
+	results := make(map[string]ValidationResult, len(inputs))
+
+	for _, key := range slices.Sorted(maps.Keys(inputs)) {
+		input := inputs[key]
+		if input.Name == "" {
+			input.Name = key
+		}
+
+		result, err := v.validate(input)
+		if err != nil {
+			return nil, fmt.Errorf("validating %s: %w", key, err)
+		}
+		results[key] = result
+	}
+
+	return results, nil
+}
+
+// ValidateFirstMatch validates input against each definition in order and
+// returns the result for the first definition the input satisfies,
+// along with that definition's name.
+//
+// If no definition matches, the returned result is invalid, contains the
+// errors from every attempted definition, and the definition name is empty.
+// Returns an error if the validation process fails or a definition does not exist.
+func (v *Validator) ValidateFirstMatch(input ValidationInput, definitions []string) (ValidationResult, string, error) {
+	if len(definitions) == 0 {
+		return ValidationResult{}, "", fmt.Errorf("no definitions given")
+	}
+
+	parsed, failed, err := v.parseInput(input)
+	if err != nil {
+		return ValidationResult{}, "", err
+	}
+	if failed != nil {
+		failed.Definition = strings.Join(definitions, resultSeparator)
+		return *failed, "", nil
+	}
+
+	combined := ValidationResult{
+		Name:       input.Name,
+		Valid:      false,
+		SchemaPath: v.schemaPath,
+		Definition: strings.Join(definitions, resultSeparator),
+	}
+	for _, definitionName := range definitions {
+		configDef, err := v.lookupDefinition(definitionName)
+		if err != nil {
+			return ValidationResult{}, "", err
+		}
+
+		result := v.checkInput(definitionName, configDef, parsed)
+		if result.Valid {
+			return result, definitionName, nil
+		}
+		combined.Errors = append(combined.Errors, errorsWithSource(result)...)
+	}
+
+	return combined, "", nil
+}
+
+// ValidateSections validates parts of one input against different
+// definitions in a single call, e.g. for a composite document whose
+// sections have their own schemas. mapping maps input paths
+// (e.g., "spec.database") to definitions (e.g., "#Database"); each section
+// is checked independently, in order of its input path, as if by Validate
+// with InputPath and Definition set. Parts of the input outside the
+// sections are not checked.
+//
+// The result is invalid if any section is, and lists the errors of all
+// sections with paths relative to the whole input. A section missing from
+// the input is an error at its path. Definition joins the section
+// definitions with " | ".
+// Returns an error if the validation process fails, an input path is
+// malformed, or a definition does not exist.
+func (v *Validator) ValidateSections(input ValidationInput, mapping map[string]string) (ValidationResult, error) {
+	if len(mapping) == 0 {
+		return ValidationResult{}, fmt.Errorf("no sections given")
+	}
+
+	parsed, failed, err := v.parseInput(input)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if failed != nil {
+		return *failed, nil
+	}
+
+	var results []ValidationResult
+	for _, section := range slices.Sorted(maps.Keys(mapping)) {
+		sectionInput := input
+		sectionInput.InputPath = section
+		sectionInput.Definition = mapping[section]
+
+		definitionName, configDef, failed, err := v.inputDefinition(sectionInput, &parsed)
+		if err != nil {
+			return ValidationResult{}, fmt.Errorf("section %s: %w", section, err)
+		}
+		if failed != nil {
+			results = append(results, *failed)
+			continue
+		}
+		results = append(results, v.checkInput(definitionName, configDef, parsed))
+	}
+
+	combined := MergeResults(results...)
+	combined.Name = input.Name
+	return combined, nil
+}
+
+// ValidateAll validates each input in order and returns one result per input.
+//
+// Inputs with Format set to FormatAuto have their format detected from the
+// file extension or content; explicit formats are used as given.
+// Returns an error only if the validation process itself fails for any input.
+func (v *Validator) ValidateAll(inputs []ValidationInput) ([]ValidationResult, error) {
+	results := make([]ValidationResult, 0, len(inputs))
+
+	for _, input := range inputs {
+		result, err := v.validate(input)
+		if err != nil {
+			return nil, fmt.Errorf("validating %s: %w", input.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ValidateDir validates every JSON, JSONC, YAML, and TOML file (.json,
+// .jsonc, .yaml, .yml, .toml) under root, recursively and in lexical
+// order, detecting each file's format from its extension. Each result is named by the file path.
+// Returns an error if the directory cannot be walked or a file cannot be read.
+func (v *Validator) ValidateDir(root string) ([]ValidationResult, error) {
+	if err := v.checkFileAccess(root); err != nil {
+		return nil, err
+	}
+
+	inputs, err := collectDirInputs(root)
+	if err != nil {
+		return nil, err
+	}
+	return v.ValidateAll(inputs)
+}
+
+// ValidateDirStream validates the same files as ValidateDir, sending each
+// result on the returned channel as soon as the file is validated, so that
+// large trees can be processed incrementally.
+//
+// The channel is closed when the walk finishes or ctx is cancelled; the
+// caller must either drain it or cancel ctx to release the walker.
+// Files or directories that cannot be read are reported as invalid results
+// named by their path, and the walk continues.
+func (v *Validator) ValidateDirStream(ctx context.Context, root string) <-chan ValidationResult {
+	results := make(chan ValidationResult)
+
+	go func() {
+		defer close(results)
+
+		send := func(result ValidationResult) error {
+			// Count first, so a receiver's snapshot includes the result
+			v.options.Stats.record(result)
+			select {
+			case results <- result:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := v.checkFileAccess(root); err != nil {
+			_ = send(v.withProvenance(createErrorResult(root, err.Error())))
+			return
+		}
+
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if err != nil {
+				return send(v.withProvenance(createErrorResult(path, err.Error())))
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if _, ok := formatFromExtension(path); !ok {
+				return nil
+			}
+
+			result, err := v.validate(ValidationInput{
+				SourceType: SourceFile,
+				FilePath:   path,
+				Format:     FormatAuto,
+				Name:       path,
+			})
+			if err != nil {
+				result = v.withProvenance(createErrorResult(path, err.Error()))
+			}
+			return send(result)
+		})
+	}()
+
+	return results
+}
+
+// collectDirInputs builds inputs for all config files under root
+func collectDirInputs(root string) ([]ValidationInput, error) {
+	var inputs []ValidationInput
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := formatFromExtension(path); !ok {
+			return nil
+		}
+
+		inputs = append(inputs, ValidationInput{
+			SourceType: SourceFile,
+			FilePath:   path,
+			Format:     FormatAuto,
+			Name:       path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking directory %s: %w", root, err)
+	}
+
+	return inputs, nil
+}
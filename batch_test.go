@@ -0,0 +1,314 @@
+package cuebridge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestValidator writes schema to a temporary file and creates a Validator for #Config
+func newTestValidator(t *testing.T, schema string) *Validator {
+	t.Helper()
+	return newTestValidatorWithOptions(t, schema, ValidationOptions{})
+}
+
+// newTestValidatorWithOptions writes schema to a temporary file and creates
+// a Validator for #Config with opts
+func newTestValidatorWithOptions(t *testing.T, schema string, opts ValidationOptions) *Validator {
+	t.Helper()
+
+	validator, err := NewValidatorWithOptions(writeTestSchema(t, schema), "#Config", opts)
+	if err != nil {
+		t.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+	return validator
+}
+
+// writeTestSchema writes schema to a temporary file and returns its path
+func writeTestSchema(t *testing.T, schema string) string {
+	t.Helper()
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+	return schemaPath
+}
+
+// TestValidateMap tests validating inputs keyed by identifier
+func TestValidateMap(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+
+	results, err := validator.ValidateMap(map[string]ValidationInput{
+		"req-1": {
+			SourceType: SourceBytes,
+			Data:       []byte(`{"name": "test"}`),
+			Format:     FormatJSON,
+		},
+		"req-2": {
+			SourceType: SourceBytes,
+			Data:       []byte(`{"wrong": "field"}`),
+			Format:     FormatJSON,
+			Name:       "custom",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ValidateMap failed: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if !results["req-1"].Valid || results["req-1"].Name != "req-1" {
+		t.Errorf("req-1 = %+v, want valid result named req-1", results["req-1"])
+	}
+	if results["req-2"].Valid || results["req-2"].Name != "custom" {
+		t.Errorf("req-2 = %+v, want invalid result named custom", results["req-2"])
+	}
+
+	_, err = validator.ValidateMap(map[string]ValidationInput{
+		"missing": {SourceType: SourceFile, FilePath: "/nonexistent/file.yaml"},
+	})
+	if err == nil {
+		t.Error("expected error for unreadable input")
+	}
+
+	// With several unreadable inputs, the first key is reported
+	unreadable := map[string]ValidationInput{}
+	for _, key := range []string{"c", "a", "d", "b", "e"} {
+		unreadable[key] = ValidationInput{SourceType: SourceFile, FilePath: "/nonexistent/" + key + ".yaml"}
+	}
+	for range 10 {
+		_, err = validator.ValidateMap(unreadable)
+		if err == nil || !strings.HasPrefix(err.Error(), "validating a:") {
+			t.Fatalf("error = %v, want one for key a", err)
+		}
+	}
+}
+
+// TestValidateFirstMatch tests selecting the first matching definition
+func TestValidateFirstMatch(t *testing.T) {
+	validator := newTestValidator(t, `
+#Config: {name: string}
+#Service: {port: int}
+#Job: {schedule: string}
+`)
+	definitions := []string{"#Service", "#Job"}
+
+	result, matched, err := validator.ValidateFirstMatch(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"schedule": "@daily"}`),
+		Format:     FormatJSON,
+		Name:       "job.json",
+	}, definitions)
+	if err != nil {
+		t.Fatalf("ValidateFirstMatch failed: %v", err)
+	}
+	if !result.Valid || matched != "#Job" {
+		t.Errorf("got Valid=%v matched=%q, want valid #Job", result.Valid, matched)
+	}
+
+	result, matched, err = validator.ValidateFirstMatch(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"port": "http"}`),
+		Format:     FormatJSON,
+		Name:       "bad.json",
+	}, definitions)
+	if err != nil {
+		t.Fatalf("ValidateFirstMatch failed: %v", err)
+	}
+	if result.Valid || matched != "" {
+		t.Errorf("got Valid=%v matched=%q, want no match", result.Valid, matched)
+	}
+	if len(result.Errors) < 2 {
+		t.Errorf("expected errors from every definition, got %v", result.Errors)
+	}
+	for _, e := range result.Errors {
+		if strings.HasPrefix(e.Path, "#") {
+			t.Errorf("Path %q should not include the definition name", e.Path)
+		}
+	}
+
+	_, _, err = validator.ValidateFirstMatch(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{}`),
+		Format:     FormatJSON,
+	}, []string{"#Missing"})
+	if err == nil {
+		t.Error("expected error for missing definition")
+	}
+}
+
+// TestValidateSections tests validating input sections against different definitions
+func TestValidateSections(t *testing.T) {
+	validator := newTestValidator(t, `
+#Config: {...}
+#Server: {port: int & >0}
+#Database: {host: string, pool: int | *10}
+`)
+	sections := map[string]string{"server": "#Server", "storage.database": "#Database"}
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPaths []string
+	}{
+		{name: "all sections valid", data: `{"server": {"port": 80}, "storage": {"database": {"host": "db"}}, "other": 1}`, wantValid: true},
+		{name: "errors in both sections", data: `{"server": {"port": 0}, "storage": {"database": {"host": 1}}}`, wantPaths: []string{"server.port", "storage.database.host"}},
+		{name: "missing section", data: `{"server": {"port": 80}}`, wantPaths: []string{"storage.database"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.ValidateSections(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "app.json",
+			}, sections)
+			if err != nil {
+				t.Fatalf("ValidateSections failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Name != "app.json" || result.Definition != "#Server | #Database" {
+				t.Errorf("got Name=%q Definition=%q", result.Name, result.Definition)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+
+	_, err := validator.ValidateSections(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"server": {}}`),
+		Format:     FormatJSON,
+	}, map[string]string{"server": "#Missing"})
+	if err == nil || !strings.Contains(err.Error(), "section server") {
+		t.Errorf("expected error for missing definition, got %v", err)
+	}
+}
+
+// TestValidateAllMixedFormats tests format detection across a batch
+func TestValidateAllMixedFormats(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"app.json":        `{"name": "app"}`,
+		"svc.yaml":        "name: svc\n",
+		"nested/bad.yml":  "wrong: field\n",
+		"nested/notes.md": "not a config",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	results, err := validator.ValidateDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ValidateDir failed: %v", err)
+	}
+
+	want := map[string]bool{
+		filepath.Join(tmpDir, "app.json"):       true,
+		filepath.Join(tmpDir, "svc.yaml"):       true,
+		filepath.Join(tmpDir, "nested/bad.yml"): false,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, result := range results {
+		if result.Valid != want[result.Name] {
+			t.Errorf("%s: Valid = %v, want %v (errors: %v)", result.Name, result.Valid, want[result.Name], result.Errors)
+		}
+	}
+
+	// Explicit formats stay authoritative, FormatAuto falls back to content
+	results, err = validator.ValidateAll([]ValidationInput{
+		{SourceType: SourceBytes, Data: []byte(`{"name": "a"}`), Format: FormatAuto, Name: "stdin"},
+		{SourceType: SourceBytes, Data: []byte("name: b\n"), Format: FormatAuto, Name: "stdin"},
+		{SourceType: SourceFile, FilePath: filepath.Join(tmpDir, "app.json"), Format: FormatYAML, Name: "as-yaml"},
+	})
+	if err != nil {
+		t.Fatalf("ValidateAll failed: %v", err)
+	}
+	for i, result := range results {
+		if !result.Valid {
+			t.Errorf("input %d: expected valid result, got %v", i, result.Errors)
+		}
+	}
+}
+
+// TestValidateDirStream tests streaming directory results and cancellation
+func TestValidateDirStream(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+
+	tmpDir := t.TempDir()
+	for i := range 5 {
+		path := filepath.Join(tmpDir, fmt.Sprintf("app%d.yaml", i))
+		content := fmt.Sprintf("name: app%d\n", i)
+		if i == 3 {
+			content = "wrong: field\n"
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	var invalid []string
+	count := 0
+	for result := range validator.ValidateDirStream(context.Background(), tmpDir) {
+		count++
+		if !result.Valid {
+			invalid = append(invalid, result.Name)
+		}
+	}
+	if count != 5 {
+		t.Errorf("got %d results, want 5", count)
+	}
+	if len(invalid) != 1 || invalid[0] != filepath.Join(tmpDir, "app3.yaml") {
+		t.Errorf("invalid results %v, want app3.yaml only", invalid)
+	}
+
+	// Cancelling stops the walk and closes the channel
+	ctx, cancel := context.WithCancel(context.Background())
+	results := validator.ValidateDirStream(ctx, tmpDir)
+	<-results
+	cancel()
+
+	count = 1
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case _, ok := <-results:
+			if !ok {
+				done = true
+				continue
+			}
+			count++
+		case <-timeout:
+			t.Fatal("channel not closed after cancellation")
+		}
+	}
+	if count >= 5 {
+		t.Errorf("got %d results after cancellation, want fewer than 5", count)
+	}
+}
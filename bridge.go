@@ -26,9 +26,14 @@
 package cuebridge
 
 import (
+	"fmt"
 	"io"
+	"log/slog"
+	"path/filepath"
+	"time"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
 )
 
 // InputSourceType represents the type of input source
@@ -43,6 +48,17 @@ const (
 	SourceBytes
 )
 
+// InputEncoding represents a transfer encoding of input data
+type InputEncoding int
+
+const (
+	// EncodingNone uses the input data as is
+	EncodingNone InputEncoding = iota
+	// EncodingBase64 decodes the input data from base64 before parsing
+	// (standard or URL-safe alphabet, padded or not; whitespace is ignored)
+	EncodingBase64
+)
+
 // DataFormat represents the format of input data
 type DataFormat int
 
@@ -51,8 +67,233 @@ const (
 	FormatJSON DataFormat = iota
 	// FormatYAML represents YAML format
 	FormatYAML
+	// FormatAuto detects JSON, JSONC, YAML, or TOML from the file extension
+	// (FilePath, or Name for non-file sources), falling back to the content
+	// (JSON or YAML only)
+	FormatAuto
+	// FormatTOML represents TOML format. Arrays of tables ([[servers]])
+	// become lists of structs.
+	FormatTOML
+	// FormatJSONC represents JSON with comments (// and /* */) and
+	// trailing commas, as used by VS Code-style config files. Exported
+	// data is plain JSON.
+	FormatJSONC
+)
+
+// Severity represents how serious a validation error is.
+// The zero value is SeverityError.
+type Severity int
+
+const (
+	// SeverityError marks a violation that makes the input invalid
+	SeverityError Severity = iota
+	// SeverityWarning marks an issue that is reported but does not fail validation
+	SeverityWarning
 )
 
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// AtLeast reports whether s is at least as severe as min
+// (SeverityError is more severe than SeverityWarning)
+func (s Severity) AtLeast(min Severity) bool {
+	return s <= min
+}
+
+// MarshalText encodes the severity as its name (e.g., in JSON output)
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "error":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	default:
+		return fmt.Errorf("unknown severity: %s", text)
+	}
+	return nil
+}
+
+// Default limits applied to input values, see ValidationOptions.
+const (
+	DefaultMaxDepth  = 256
+	DefaultMaxValues = 1000000
+)
+
+// ValidationOptions configures optional validator behavior.
+// The zero value matches the behavior of NewValidator.
+type ValidationOptions struct {
+	// ReportDeprecated emits a warning for each input field whose schema
+	// field is annotated with @deprecated (e.g., @deprecated("use name"))
+	ReportDeprecated bool
+	// ReportRecommended emits a warning for each schema field annotated with
+	// @recommended (e.g., @recommended("set an owner for paging")) that is
+	// missing from the input. Such fields should be optional in the schema.
+	ReportRecommended bool
+	// StrictTopLevel reports an error for each top-level input field that
+	// the definition does not declare, even if the definition is open
+	// (e.g., ends in "..."). Nested structs keep their own openness, so
+	// only misspelled or unknown sections are rejected. Fields matching a
+	// pattern constraint (e.g., [=~"^x-"]: string) count as declared. With
+	// ValidationInput.InputPath, the fields at that path are checked.
+	StrictTopLevel bool
+	// CaptureSourceLines fills ValidationError.SourceLine with the text of
+	// the input line each error refers to, for every source type
+	CaptureSourceLines bool
+	// PreferInputPositions reports Line/Column from the input side when an
+	// error carries positions in both the schema and the input
+	PreferInputPositions bool
+	// PathFormatter, if set, formats ValidationError.Path from the path
+	// elements of the field in place of the default dotted notation
+	// (e.g., "spec.containers.0.image"), for bracket or other styles.
+	// ValidationError.Pointer is unaffected.
+	PathFormatter func(elements []string) string
+	// FloatTolerance accepts a number that differs from a float the schema
+	// requires (e.g., ratio: 0.3) or from an inclusive bound (e.g., <=1.0)
+	// by at most FloatEpsilon relative to their magnitude, such as
+	// 0.30000000000000004 from float64 arithmetic. Integers are still
+	// compared exactly. Data exported with the unified value (e.g., by
+	// Canonicalize) has the schema's number in place of the near one.
+	// This deviates from CUE, which compares numbers exactly, and is off
+	// by default.
+	FloatTolerance bool
+	// FloatEpsilon is the tolerance for FloatTolerance
+	// (0 means DefaultFloatEpsilon)
+	FloatEpsilon float64
+	// PostValidate runs custom checks on the unified value after schema
+	// validation succeeds. Returned errors are added to the result, and any
+	// with SeverityError make it invalid.
+	PostValidate func(unified cue.Value) []ValidationError
+	// YAMLTagHandlers maps custom YAML tags (e.g., "!Ref") to handlers
+	// that convert the tagged nodes into CUE expressions. A custom tag
+	// without a handler is reported as a parse error.
+	YAMLTagHandlers map[string]YAMLTagHandler
+	// KeepLineEndings disables converting CRLF and CR line endings in
+	// inputs to LF before parsing. By default they are converted, so that
+	// Windows and classic Mac OS files parse and report positions the
+	// same way as Unix files.
+	KeepLineEndings bool
+	// StrictYAML reports each repeated key in a YAML mapping as an error at
+	// the line of the repetition, instead of letting the values unify
+	StrictYAML bool
+	// KeyCaseNormalization renames the object keys of every input, at all
+	// levels, before validation (e.g., KeyCaseSnakeToCamel accepts
+	// max_retries for a schema field maxRetries), so that one schema
+	// validates both styles. Only keys are renamed, never string values;
+	// error paths and exported data use the renamed keys. Two keys that
+	// become the same (e.g., max_retries and maxRetries) are unified like
+	// a repeated key.
+	KeyCaseNormalization KeyCase
+	// Sandbox disables all file access during validation: SourceFile inputs
+	// and file-based methods such as ValidateDir return an error instead of
+	// reading disk. SourceReader and SourceBytes inputs remain usable.
+	// Loading the schema is not affected; use NewValidatorFromValue to
+	// avoid the filesystem entirely.
+	Sandbox bool
+	// Package names the CUE package to load when the schema path is a
+	// directory holding files of several packages (e.g., "schemas").
+	// For a schema file it must match the file's package clause.
+	// Empty loads the only package in a directory, or any file.
+	Package string
+	// AllowedExtraFields lists field names accepted at any level even where
+	// a closed definition does not declare them (e.g., "metadata"), with
+	// an optional trailing "*" to match a prefix (e.g., "x-*" for extension
+	// fields). Such fields are not checked but are kept in data exported
+	// with the unified value (e.g., by Canonicalize) and in hashes. Fields
+	// the definition declares are validated as usual.
+	AllowedExtraFields []string
+	// DisallowedImports lists packages the schema may not import, directly
+	// or through the packages it imports (e.g., "tool/os" or "tool" for all
+	// of the tool packages), for schemas supplied by untrusted users.
+	// NewValidatorWithOptions returns an error naming the first disallowed
+	// import and its position. Schemas given as a cue.Value are not checked.
+	DisallowedImports []string
+	// DiscriminatorField and DiscriminatorMap select the definition for each
+	// input by the value of one of its fields, e.g. Kubernetes-style "kind":
+	// DiscriminatorMap maps field values (e.g., "Service") to definitions
+	// (e.g., "#Service"). An input whose field is missing, not a string,
+	// or not mapped is invalid. ValidationInput.Definition takes precedence.
+	// NewValidatorWithOptions returns an error if only one of the two is set
+	// or the schema does not define a mapped definition.
+	DiscriminatorField string
+	DiscriminatorMap   map[string]string
+	// Overrides sets schema fields when the validator is created, e.g. from
+	// command-line key=value flags. Keys are field paths relative to the
+	// definition (e.g., "spec.replicas"); values are taken as strings for
+	// string fields and parsed as CUE literals otherwise (e.g., "5", "true").
+	// The values are unified into the schema, so inputs must agree with them.
+	// NewValidatorWithOptions returns an error for an unknown path or a value
+	// that does not fit the field.
+	Overrides map[string]string
+	// CacheSize enables a cache of up to CacheSize results in Validate and
+	// the methods built on it: an input whose bytes, format, Definition,
+	// InputPath, and Proto3JSON match an earlier one returns the stored
+	// result (renamed to the input's Name) without re-validating. Parse
+	// failures and results whose messages quote the input's Name are not
+	// cached, since they would name the wrong input.
+	// The cache belongs to the Validator and assumes its schema does not
+	// change; create a new Validator after reloading a schema. PostValidate
+	// checks must depend only on the input for cached results to stay
+	// correct. 0 disables the cache.
+	CacheSize int
+	// Logger, if set, receives a debug-level record for each input checked
+	// by Validate and the methods built on it (e.g., ValidateFile, ValidateAll,
+	// ValidateDir), with the schema path, input name, duration, and error count.
+	// Nothing is logged when Logger is nil.
+	Logger *slog.Logger
+	// Stats, if set, counts each result sent by ValidateDirStream or yielded
+	// by ValidateJSONStream as it is produced, for live progress reporting.
+	// Nothing is counted when Stats is nil.
+	Stats *Stats
+	// Export controls the encoding of data returned by validation methods
+	Export ExportOptions
+	// MaxDepth limits how deeply input values may nest
+	// (0 means DefaultMaxDepth, negative means no limit)
+	MaxDepth int
+	// MaxValues limits the total number of values (fields, list elements,
+	// and scalars) in an input (0 means DefaultMaxValues, negative means no limit)
+	//
+	// Both limits apply to every parsed input, to the merged value of
+	// ValidateLayered, to the previous version given to ValidateAndCompare,
+	// and to ValidateField values. They do not apply to ValidateForeignValue,
+	// whose value comes from the caller's own CUE program.
+	//
+	// Only the size of the input is limited: schema evaluation and the
+	// unification and validation of an input against the schema are not
+	// bounded. The defaults reject inputs that earlier versions accepted;
+	// set a negative value to restore the previous behavior.
+	MaxValues int
+}
+
+// ExportOptions controls how validated values are encoded by the methods
+// that return data (e.g., ValidateLayered).
+// The zero value uses 2-space indentation, schema field order, and block-style YAML.
+type ExportOptions struct {
+	// Indent is the indentation width in spaces (0 means 2)
+	Indent int
+	// SortKeys orders object keys alphabetically instead of by field order
+	SortKeys bool
+	// YAMLFlowStyle writes YAML mappings and sequences in flow style ({...}, [...])
+	YAMLFlowStyle bool
+	// PreserveComments keeps the comments of a YAML input in YAML output,
+	// attached to the same fields. Comments on a value that changed (e.g.,
+	// replaced by a layered override) are dropped.
+	PreserveComments bool
+}
+
 // Validator validates data against a CUE schema.
 // Create a Validator with NewValidator and reuse it for multiple validations.
 type Validator struct {
@@ -60,6 +301,9 @@ type Validator struct {
 	definitionName string
 	ctx            *cue.Context
 	compiledSchema cue.Value
+	schemaFiles    []*ast.File
+	options        ValidationOptions
+	cache          *resultCache
 }
 
 // ValidationInput specifies the input data to validate.
@@ -74,33 +318,90 @@ type ValidationInput struct {
 	Reader io.Reader
 	// Data is the byte slice to use directly (when SourceType is SourceBytes)
 	Data []byte
-	// Format specifies the data format (FormatJSON or FormatYAML)
+	// Format specifies the data format (FormatJSON, FormatYAML, or FormatAuto)
 	Format DataFormat
+	// Encoding specifies how the data is encoded (e.g., EncodingBase64 for
+	// configs taken from a Kubernetes Secret). Format applies to the
+	// decoded data, and error lines refer to it.
+	Encoding InputEncoding
+	// Definition overrides the validator's definition for this input
+	// (e.g., "#Spec"). Returns an error from Validate if the schema
+	// does not define it.
+	Definition string
+	// InputPath selects the part of the input to validate (e.g., "spec.template"),
+	// leaving the rest of the input unchecked. Error paths remain relative
+	// to the whole input. If the input has no value at InputPath,
+	// the result is invalid with a single error naming the path.
+	InputPath string
+	// Proto3JSON applies proto3 JSON mapping conventions when Format is FormatJSON
+	// (e.g., for gRPC JSON transcoding payloads). Currently handled:
+	//   - A field set to null is treated as absent
+	Proto3JSON bool
 }
 
 // ValidationResult contains the result of validating a single input.
 type ValidationResult struct {
 	// Name is the identifier of the validated input
-	Name string
-	// Valid is true if validation succeeded
-	Valid bool
-	// Errors contains validation errors (empty if Valid is true)
-	Errors []ValidationError
+	Name string `json:"name"`
+	// Valid is true if validation succeeded (no errors with SeverityError)
+	Valid bool `json:"valid"`
+	// Errors contains validation errors and warnings
+	// (only warnings, if any, when Valid is true)
+	Errors []ValidationError `json:"errors"`
+	// SchemaPath is the schema file the input was validated against
+	// (empty for validators created from a cue.Value)
+	SchemaPath string `json:"schema_path,omitempty"`
+	// Definition is the definition the input was validated against (e.g., "#Config")
+	Definition string `json:"definition,omitempty"`
+	// ParseFailed is true when the input could not be parsed as JSON or YAML
+	// (including duplicate keys under ValidationOptions.StrictYAML), so it
+	// was never checked against the schema
+	ParseFailed bool `json:"parse_failed,omitempty"`
+	// Duration is how long reading and validating the input took, set by
+	// Validate and the methods built on it (zero for other methods).
+	// It is not included in JSON output, which stays reproducible.
+	Duration time.Duration `json:"-"`
 }
 
 // ValidationError represents a single validation error.
 type ValidationError struct {
 	// Line is the line number in the source (0 if unknown)
-	Line int
+	Line int `json:"line"`
 	// Column is the column number in the source (0 if unknown)
-	Column int
+	Column int `json:"column"`
 	// Path is the field path (e.g., "spec.replicas")
-	Path string
-	// Message is the error message
-	Message string
+	Path string `json:"path"`
+	// Pointer is the RFC 6901 JSON Pointer to the field in the input
+	// (e.g., "/spec/containers/0/image"), or empty if the error has no path
+	Pointer string `json:"pointer,omitempty"`
+	// Message is the error message, which may be a friendlier rewrite of
+	// the message CUE reported
+	Message string `json:"message"`
+	// RawMessage is the unmodified CUE error message, for debugging, or
+	// empty if the error did not come from CUE (e.g., a parse failure)
+	RawMessage string `json:"raw_message,omitempty"`
+	// Severity is SeverityError unless the issue is only a warning
+	Severity Severity `json:"severity"`
+	// GotType is the kind of the input value at Path (e.g., "string"),
+	// or empty if the input has no value there
+	GotType string `json:"got_type,omitempty"`
+	// SourceLine is the text of the input line the error refers to
+	// (empty unless ValidationOptions.CaptureSourceLines is set)
+	SourceLine string `json:"source_line,omitempty"`
+	// Condition is the schema condition under which the failing constraint
+	// applies, when the field is declared inside if-clauses
+	// (e.g., `type == "gpu"`), or empty
+	Condition string `json:"condition,omitempty"`
+	// SchemaPath and Definition are the schema and definition the error
+	// came from, set when errors of several validations are combined
+	// (MergeResults, ValidateFirstMatch, UnionValidator), or empty
+	SchemaPath string `json:"schema_path,omitempty"`
+	Definition string `json:"definition,omitempty"`
 }
 
 // NewValidator creates a new Validator by loading and compiling a CUE schema file.
+// schemaPath may also be a directory, whose CUE package is loaded as a whole
+// (see ValidationOptions.Package to pick one of several).
 // The definitionName parameter specifies which definition to use for validation
 // (e.g., "#Config", "#ServiceConfig").
 //
@@ -109,7 +410,64 @@ type ValidationError struct {
 //   - The schema has CUE syntax errors
 //   - The schema does not define the specified definition
 func NewValidator(schemaPath string, definitionName string) (*Validator, error) {
-	return newValidator(schemaPath, definitionName)
+	return newValidator(schemaPath, definitionName, ValidationOptions{})
+}
+
+// NewValidatorWithOptions creates a new Validator like NewValidator,
+// with optional behavior configured by opts.
+func NewValidatorWithOptions(schemaPath string, definitionName string, opts ValidationOptions) (*Validator, error) {
+	return newValidator(schemaPath, definitionName, opts)
+}
+
+// NewValidatorVerbose creates a new Validator like NewValidator and also
+// returns warnings about schema issues that do not prevent compilation,
+// all with SeverityWarning:
+//   - The definition accepts any value
+//   - Findings of LintSchema, such as hidden definitions that nothing references
+func NewValidatorVerbose(schemaPath string, definitionName string) (*Validator, []ValidationError, error) {
+	return newValidatorVerbose(schemaPath, definitionName)
+}
+
+// NewValidatorFromValue creates a new Validator from an already compiled
+// CUE schema value, e.g. one built programmatically.
+// The validator parses inputs in the schema's own cue.Context,
+// so the value must have been created by a cue.Context.
+//
+// Returns an error if:
+//   - The value has no context or is an error value
+//   - The schema does not define the specified definition
+func NewValidatorFromValue(schema cue.Value, definitionName string) (*Validator, error) {
+	return newValidatorFromValue(schema, "", definitionName, ValidationOptions{})
+}
+
+// NewValidatorFromSchemaFile creates a new Validator from a schema file in
+// any supported format, inferred from the extension: a JSON Schema document
+// in .json, .yaml, or .yml is converted to CUE, and any other file is loaded
+// as CUE (see NewValidator).
+//
+// A JSON Schema's $defs and definitions become CUE definitions (e.g.,
+// "$defs/port" becomes #port). Unless definitionName names one of them,
+// it refers to the root schema.
+//
+// Returns an error if the file cannot be read, converted, or compiled.
+func NewValidatorFromSchemaFile(schemaPath string, definitionName string) (*Validator, error) {
+	return newValidatorFromSchemaFile(schemaPath, definitionName)
+}
+
+// NewValidatorFromModule creates a new Validator from a package of a CUE
+// module published to a registry. modulePath includes the exact version
+// (e.g., "example.com/schemas@v1.2.0"), and packageName is the package
+// directory within the module (e.g., "k8s/apps", or "" for the module root).
+//
+// The registry is configured like the cue command: CUE_REGISTRY selects
+// it and credentials from "cue login" are used. Fetched modules are
+// cached in the CUE cache directory (CUE_CACHE_DIR) and reused.
+//
+// Returns an error if the module cannot be fetched (e.g., not found or
+// unauthorized), its package fails to load or compile, or the schema does
+// not define the specified definition.
+func NewValidatorFromModule(modulePath string, packageName string, definitionName string) (*Validator, error) {
+	return newValidatorFromModule(modulePath, packageName, definitionName)
 }
 
 // Validate validates a single input against the schema.
@@ -120,3 +478,20 @@ func NewValidator(schemaPath string, definitionName string) (*Validator, error)
 func (v *Validator) Validate(input ValidationInput) (ValidationResult, error) {
 	return v.validate(input)
 }
+
+// ValidateFile validates a file in the given format.
+// The result is named by the file's base name (e.g., "config.yaml").
+func (v *Validator) ValidateFile(path string, format DataFormat) (ValidationResult, error) {
+	return v.validate(ValidationInput{
+		SourceType: SourceFile,
+		FilePath:   path,
+		Format:     format,
+		Name:       filepath.Base(path),
+	})
+}
+
+// ValidateFileAuto validates a file, detecting its format from the extension
+// (see FormatAuto).
+func (v *Validator) ValidateFileAuto(path string) (ValidationResult, error) {
+	return v.ValidateFile(path, FormatAuto)
+}
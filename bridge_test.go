@@ -2,9 +2,14 @@ package cuebridge
 
 import (
 	"bytes"
+	"encoding/json"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"cuelang.org/go/cue"
 )
 
 // TestEndToEnd tests the complete validation flow
@@ -256,3 +261,321 @@ func TestErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateFile tests the file convenience wrappers
+func TestValidateFile(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "app.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"name": "app"}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	yamlPath := filepath.Join(tmpDir, "svc.yml")
+	if err := os.WriteFile(yamlPath, []byte("wrong: field\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	result, err := validator.ValidateFile(jsonPath, FormatJSON)
+	if err != nil {
+		t.Fatalf("ValidateFile failed: %v", err)
+	}
+	if !result.Valid || result.Name != "app.json" {
+		t.Errorf("got Valid=%v Name=%q, want valid app.json", result.Valid, result.Name)
+	}
+
+	result, err = validator.ValidateFileAuto(yamlPath)
+	if err != nil {
+		t.Fatalf("ValidateFileAuto failed: %v", err)
+	}
+	if result.Valid || result.Name != "svc.yml" {
+		t.Errorf("got Valid=%v Name=%q, want invalid svc.yml", result.Valid, result.Name)
+	}
+}
+
+// TestResultProvenance tests that results record the schema and definition
+func TestResultProvenance(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	if err := os.WriteFile(schemaPath, []byte(`#Service: {port: int}`), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	validator, err := NewValidator(schemaPath, "#Service")
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	for _, data := range []string{`{"port": 80}`, `{"port": "http"}`, `{"port": `} {
+		result, err := validator.Validate(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(data),
+			Format:     FormatJSON,
+			Name:       "service.json",
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if result.SchemaPath != schemaPath || result.Definition != "#Service" {
+			t.Errorf("%s: got SchemaPath=%q Definition=%q", data, result.SchemaPath, result.Definition)
+		}
+	}
+}
+
+// TestPostValidate tests custom checks on the unified value
+func TestPostValidate(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {min: int, max: int}`, ValidationOptions{
+		PostValidate: func(unified cue.Value) []ValidationError {
+			minValue, _ := unified.LookupPath(cue.ParsePath("min")).Int64()
+			maxValue, _ := unified.LookupPath(cue.ParsePath("max")).Int64()
+			if minValue > maxValue {
+				return []ValidationError{{Path: "min", Message: "min must not exceed max"}}
+			}
+			return nil
+		},
+	})
+
+	tests := []struct {
+		data      string
+		wantValid bool
+	}{
+		{data: `{"min": 1, "max": 3}`, wantValid: true},
+		{data: `{"min": 5, "max": 3}`, wantValid: false},
+		{data: `{"min": "x", "max": 3}`, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		result, err := validator.Validate(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(tt.data),
+			Format:     FormatJSON,
+			Name:       "config.json",
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if result.Valid != tt.wantValid {
+			t.Errorf("%s: Valid = %v, want %v", tt.data, result.Valid, tt.wantValid)
+		}
+	}
+}
+
+// TestValidateInputPath tests validating part of an input against another definition
+func TestValidateInputPath(t *testing.T) {
+	validator := newTestValidator(t, `
+#Config: {name: string, spec: #Spec}
+#Spec: {replicas: int & >0}
+`)
+
+	tests := []struct {
+		name       string
+		data       string
+		inputPath  string
+		definition string
+		wantValid  bool
+		wantPath   string
+		wantErr    bool
+	}{
+		{
+			name:       "valid subtree, rest ignored",
+			data:       `{"spec": {"replicas": 2}, "other": true}`,
+			inputPath:  "spec",
+			definition: "#Spec",
+			wantValid:  true,
+		},
+		{
+			name:       "invalid subtree reports full path",
+			data:       `{"spec": {"replicas": 0}}`,
+			inputPath:  "spec",
+			definition: "#Spec",
+			wantValid:  false,
+			wantPath:   "spec.replicas",
+		},
+		{
+			name:       "closed definition at path",
+			data:       `{"spec": {"replicas": 1, "extra": 1}}`,
+			inputPath:  "spec",
+			definition: "#Spec",
+			wantValid:  false,
+			wantPath:   "spec.extra",
+		},
+		{
+			name:       "definition without path",
+			data:       `{"replicas": 1}`,
+			definition: "#Spec",
+			wantValid:  true,
+		},
+		{
+			name:       "missing input path",
+			data:       `{"name": "app"}`,
+			inputPath:  "spec",
+			definition: "#Spec",
+			wantValid:  false,
+			wantPath:   "spec",
+		},
+		{
+			name:       "missing definition",
+			data:       `{"spec": {}}`,
+			inputPath:  "spec",
+			definition: "#Missing",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+				InputPath:  tt.inputPath,
+				Definition: tt.definition,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Definition != tt.definition {
+				t.Errorf("Definition = %q, want %q", result.Definition, tt.definition)
+			}
+			if tt.wantPath != "" && result.Errors[0].Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q (errors: %v)", result.Errors[0].Path, tt.wantPath, result.Errors)
+			}
+		})
+	}
+}
+
+// TestLogger tests debug logging of validation outcomes
+func TestLogger(t *testing.T) {
+	schema := `#Config: {port: int & >0}`
+
+	var output bytes.Buffer
+	validator := newTestValidatorWithOptions(t, schema, ValidationOptions{
+		Logger: slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	_, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"port": 0}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON log record, got %q: %v", output.String(), err)
+	}
+	if record["level"] != "DEBUG" || record["input"] != "config.json" || record["errors"] != float64(1) || record["valid"] != false {
+		t.Errorf("unexpected log record: %v", record)
+	}
+	if _, ok := record["duration"]; !ok {
+		t.Errorf("log record has no duration: %v", record)
+	}
+
+	// Records below the handler level are dropped
+	output.Reset()
+	validator = newTestValidatorWithOptions(t, schema, ValidationOptions{
+		Logger: slog.New(slog.NewJSONHandler(&output, nil)),
+	})
+	if _, err := validator.Validate(ValidationInput{SourceType: SourceBytes, Data: []byte(`{"port": 1}`), Format: FormatJSON}); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected no output at info level, got %q", output.String())
+	}
+}
+
+// TestOverrides tests setting schema fields from key=value strings
+func TestOverrides(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	schema := `#Config: {
+	name:     string
+	env:      *"dev" | string
+	replicas: *1 | int & <=10
+	debug:    *false | bool
+}`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		data      string
+		wantValid bool
+		wantErr   string
+	}{
+		{
+			name:      "typed overrides",
+			overrides: map[string]string{"replicas": "5", "debug": "true", "env": "prod"},
+			data:      `{"name": "app"}`,
+			wantValid: true,
+		},
+		{
+			name:      "input agrees",
+			overrides: map[string]string{"replicas": "5"},
+			data:      `{"name": "app", "replicas": 5}`,
+			wantValid: true,
+		},
+		{
+			name:      "input conflicts",
+			overrides: map[string]string{"replicas": "5"},
+			data:      `{"name": "app", "replicas": 3}`,
+			wantValid: false,
+		},
+		{
+			name:      "unknown path",
+			overrides: map[string]string{"spec.replicas": "5"},
+			wantErr:   "#Config does not define spec.replicas",
+		},
+		{
+			name:      "wrong type",
+			overrides: map[string]string{"replicas": "many"},
+			wantErr:   "override replicas=many: want int",
+		},
+		{
+			name:      "violates constraint",
+			overrides: map[string]string{"replicas": "50"},
+			wantErr:   "override replicas=50:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := NewValidatorWithOptions(schemaPath, "#Config", ValidationOptions{Overrides: tt.overrides})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewValidatorWithOptions failed: %v", err)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package cuebridge
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+
+	"cuelang.org/go/cue"
+)
+
+// Builder configures and creates a Validator step by step, as an
+// alternative to filling in ValidationOptions:
+//
+//	validator, err := cuebridge.NewBuilder("schema.cue", "#Config").
+//	    WithReportDeprecated(true).
+//	    WithMaxDepth(32).
+//	    Build()
+//
+// Each With method sets the ValidationOptions field of the same name and
+// returns the builder. A builder without any With calls builds the same
+// Validator as NewValidator.
+type Builder struct {
+	schemaPath     string
+	definitionName string
+	options        ValidationOptions
+}
+
+// NewBuilder starts configuring a Validator for the given schema file and
+// definition (see NewValidator)
+func NewBuilder(schemaPath string, definitionName string) *Builder {
+	return &Builder{schemaPath: schemaPath, definitionName: definitionName}
+}
+
+// WithOptions replaces all options set so far with opts
+func (b *Builder) WithOptions(opts ValidationOptions) *Builder {
+	b.options = opts
+	return b
+}
+
+// WithReportDeprecated sets ValidationOptions.ReportDeprecated
+func (b *Builder) WithReportDeprecated(enabled bool) *Builder {
+	b.options.ReportDeprecated = enabled
+	return b
+}
+
+// WithReportRecommended sets ValidationOptions.ReportRecommended
+func (b *Builder) WithReportRecommended(enabled bool) *Builder {
+	b.options.ReportRecommended = enabled
+	return b
+}
+
+// WithStrictTopLevel sets ValidationOptions.StrictTopLevel
+func (b *Builder) WithStrictTopLevel(enabled bool) *Builder {
+	b.options.StrictTopLevel = enabled
+	return b
+}
+
+// WithCaptureSourceLines sets ValidationOptions.CaptureSourceLines
+func (b *Builder) WithCaptureSourceLines(enabled bool) *Builder {
+	b.options.CaptureSourceLines = enabled
+	return b
+}
+
+// WithPreferInputPositions sets ValidationOptions.PreferInputPositions
+func (b *Builder) WithPreferInputPositions(enabled bool) *Builder {
+	b.options.PreferInputPositions = enabled
+	return b
+}
+
+// WithPathFormatter sets ValidationOptions.PathFormatter
+func (b *Builder) WithPathFormatter(formatter func(elements []string) string) *Builder {
+	b.options.PathFormatter = formatter
+	return b
+}
+
+// WithFloatTolerance sets ValidationOptions.FloatTolerance and FloatEpsilon
+func (b *Builder) WithFloatTolerance(epsilon float64) *Builder {
+	b.options.FloatTolerance = true
+	b.options.FloatEpsilon = epsilon
+	return b
+}
+
+// WithPostValidate sets ValidationOptions.PostValidate
+func (b *Builder) WithPostValidate(check func(unified cue.Value) []ValidationError) *Builder {
+	b.options.PostValidate = check
+	return b
+}
+
+// WithYAMLTagHandler adds a handler for a custom YAML tag
+// to ValidationOptions.YAMLTagHandlers
+func (b *Builder) WithYAMLTagHandler(tag string, handler YAMLTagHandler) *Builder {
+	if b.options.YAMLTagHandlers == nil {
+		b.options.YAMLTagHandlers = map[string]YAMLTagHandler{}
+	}
+	b.options.YAMLTagHandlers[tag] = handler
+	return b
+}
+
+// WithKeepLineEndings sets ValidationOptions.KeepLineEndings
+func (b *Builder) WithKeepLineEndings(enabled bool) *Builder {
+	b.options.KeepLineEndings = enabled
+	return b
+}
+
+// WithKeyCaseNormalization sets ValidationOptions.KeyCaseNormalization
+func (b *Builder) WithKeyCaseNormalization(keyCase KeyCase) *Builder {
+	b.options.KeyCaseNormalization = keyCase
+	return b
+}
+
+// WithStrictYAML sets ValidationOptions.StrictYAML
+func (b *Builder) WithStrictYAML(enabled bool) *Builder {
+	b.options.StrictYAML = enabled
+	return b
+}
+
+// WithSandbox sets ValidationOptions.Sandbox
+func (b *Builder) WithSandbox(enabled bool) *Builder {
+	b.options.Sandbox = enabled
+	return b
+}
+
+// WithPackage sets ValidationOptions.Package
+func (b *Builder) WithPackage(name string) *Builder {
+	b.options.Package = name
+	return b
+}
+
+// WithAllowedExtraFields adds field names to ValidationOptions.AllowedExtraFields
+func (b *Builder) WithAllowedExtraFields(names ...string) *Builder {
+	b.options.AllowedExtraFields = append(b.options.AllowedExtraFields, names...)
+	return b
+}
+
+// WithDisallowedImports adds packages to ValidationOptions.DisallowedImports
+func (b *Builder) WithDisallowedImports(importPaths ...string) *Builder {
+	b.options.DisallowedImports = append(b.options.DisallowedImports, importPaths...)
+	return b
+}
+
+// WithDiscriminator sets ValidationOptions.DiscriminatorField and adds
+// mappings to ValidationOptions.DiscriminatorMap
+func (b *Builder) WithDiscriminator(field string, definitions map[string]string) *Builder {
+	b.options.DiscriminatorField = field
+	if b.options.DiscriminatorMap == nil {
+		b.options.DiscriminatorMap = map[string]string{}
+	}
+	maps.Copy(b.options.DiscriminatorMap, definitions)
+	return b
+}
+
+// WithOverride adds a field override to ValidationOptions.Overrides
+func (b *Builder) WithOverride(path string, value string) *Builder {
+	if b.options.Overrides == nil {
+		b.options.Overrides = map[string]string{}
+	}
+	b.options.Overrides[path] = value
+	return b
+}
+
+// WithCacheSize sets ValidationOptions.CacheSize
+func (b *Builder) WithCacheSize(size int) *Builder {
+	b.options.CacheSize = size
+	return b
+}
+
+// WithLogger sets ValidationOptions.Logger
+func (b *Builder) WithLogger(logger *slog.Logger) *Builder {
+	b.options.Logger = logger
+	return b
+}
+
+// WithStats sets ValidationOptions.Stats
+func (b *Builder) WithStats(stats *Stats) *Builder {
+	b.options.Stats = stats
+	return b
+}
+
+// WithExport sets ValidationOptions.Export
+func (b *Builder) WithExport(export ExportOptions) *Builder {
+	b.options.Export = export
+	return b
+}
+
+// WithMaxDepth sets ValidationOptions.MaxDepth
+func (b *Builder) WithMaxDepth(depth int) *Builder {
+	b.options.MaxDepth = depth
+	return b
+}
+
+// WithMaxValues sets ValidationOptions.MaxValues
+func (b *Builder) WithMaxValues(count int) *Builder {
+	b.options.MaxValues = count
+	return b
+}
+
+// Build creates the Validator. The builder can be changed and built again
+// without affecting validators it has already built.
+//
+// Returns the same errors as NewValidatorWithOptions.
+func (b *Builder) Build() (*Validator, error) {
+	opts := b.options
+	opts.YAMLTagHandlers = maps.Clone(opts.YAMLTagHandlers)
+	opts.AllowedExtraFields = slices.Clone(opts.AllowedExtraFields)
+	opts.DisallowedImports = slices.Clone(opts.DisallowedImports)
+	opts.DiscriminatorMap = maps.Clone(opts.DiscriminatorMap)
+	opts.Overrides = maps.Clone(opts.Overrides)
+	return newValidator(b.schemaPath, b.definitionName, opts)
+}
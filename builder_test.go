@@ -0,0 +1,65 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestBuilder tests configuring a validator through the builder
+func TestBuilder(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	schema := `#Config: {name: string @deprecated("use title"), title?: string, replicas: *1 | int}`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	// A builder without options matches NewValidator
+	built, err := NewBuilder(schemaPath, "#Config").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	plain, err := NewValidator(schemaPath, "#Config")
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	if !reflect.DeepEqual(built.options, plain.options) || built.definitionName != plain.definitionName || built.schemaPath != plain.schemaPath {
+		t.Errorf("zero builder differs from NewValidator: %+v vs %+v", built.options, plain.options)
+	}
+
+	builder := NewBuilder(schemaPath, "#Config").
+		WithReportDeprecated(true).
+		WithOverride("replicas", "3").
+		WithMaxDepth(8)
+	validator, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !validator.options.ReportDeprecated || validator.options.MaxDepth != 8 {
+		t.Errorf("options not applied: %+v", validator.options)
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "app", "replicas": 3}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !result.Valid || len(result.Errors) != 1 || result.Errors[0].Severity != SeverityWarning {
+		t.Errorf("expected a valid result with one deprecation warning, got %+v", result)
+	}
+
+	// Later changes to the builder do not affect built validators
+	builder.WithOverride("replicas", "5")
+	if validator.options.Overrides["replicas"] != "3" {
+		t.Errorf("built validator changed: Overrides = %v", validator.options.Overrides)
+	}
+
+	if _, err := NewBuilder(schemaPath, "#Missing").Build(); err == nil {
+		t.Error("expected error for missing definition")
+	}
+}
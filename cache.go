@@ -0,0 +1,146 @@
+package cuebridge
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// resultCacheKey identifies a validation by the hash of everything that
+// affects its result apart from the validator's own schema
+type resultCacheKey [sha256.Size]byte
+
+// resultCache is a least-recently-used cache of validation results
+type resultCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *resultCacheEntry, most recently used first
+	entries map[resultCacheKey]*list.Element
+}
+
+// resultCacheEntry is a cached result and its key
+type resultCacheEntry struct {
+	key    resultCacheKey
+	result ValidationResult
+}
+
+// newResultCache creates a cache holding up to size results
+func newResultCache(size int) *resultCache {
+	return &resultCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[resultCacheKey]*list.Element{},
+	}
+}
+
+// resultCacheKeyFor hashes the input data together with the input settings
+// that change how it is validated. The input name is not part of the key,
+// so results that mention it are not cached (see mentionsName).
+func resultCacheKeyFor(input ValidationInput, format DataFormat, data []byte) resultCacheKey {
+	hash := sha256.New()
+	for _, s := range []string{input.Definition, input.InputPath} {
+		binary.Write(hash, binary.LittleEndian, uint64(len(s)))
+		hash.Write([]byte(s))
+	}
+	binary.Write(hash, binary.LittleEndian, int64(format))
+	binary.Write(hash, binary.LittleEndian, input.Proto3JSON)
+	hash.Write(data)
+
+	var key resultCacheKey
+	hash.Sum(key[:0])
+	return key
+}
+
+// get returns a copy of the cached result for key, if any
+func (c *resultCache) get(key resultCacheKey) (ValidationResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return ValidationResult{}, false
+	}
+	c.order.MoveToFront(element)
+	return cloneResult(element.Value.(*resultCacheEntry).result), true
+}
+
+// put stores a copy of result under key, evicting the least recently used
+// result when the cache is full
+func (c *resultCache) put(key resultCacheKey, result ValidationResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*resultCacheEntry).result = cloneResult(result)
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&resultCacheEntry{key: key, result: cloneResult(result)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*resultCacheEntry).key)
+	}
+}
+
+// cloneResult copies result so that callers cannot modify cached errors
+func cloneResult(result ValidationResult) ValidationResult {
+	result.Errors = slices.Clone(result.Errors)
+	return result
+}
+
+// validateCached validates input through the result cache: the input is
+// read once, and an identical earlier input returns the stored result
+// under this input's name
+func (v *Validator) validateCached(input ValidationInput) (ValidationResult, error) {
+	data, err := v.readInput(input)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("reading input: %w", err)
+	}
+
+	// Validate the data already read, with the format the original input resolves to
+	format := resolveFormat(input, data)
+	key := resultCacheKeyFor(input, format, data)
+	if result, ok := v.cache.get(key); ok {
+		result.Name = input.Name
+		return result, nil
+	}
+
+	input.SourceType = SourceBytes
+	input.Data = data
+	input.Encoding = EncodingNone
+	input.Format = format
+	result, err := v.validateInput(input)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	if !mentionsName(result, input.Name) {
+		v.cache.put(key, result)
+	}
+	return result, nil
+}
+
+// mentionsName reports whether result depends on the input name beyond
+// its Name field: parse failures and errors whose messages quote the name
+// (e.g., in a position such as "config.yaml:3:1"), which would be wrong
+// for another input with the same bytes
+func mentionsName(result ValidationResult, name string) bool {
+	if result.ParseFailed {
+		return true
+	}
+	if name == "" {
+		return false
+	}
+	for _, e := range result.Errors {
+		if strings.Contains(e.Message, name) || strings.Contains(e.RawMessage, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package cuebridge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCachedTestValidator creates a validator for #Config with a result cache
+func newCachedTestValidator(tb testing.TB, schema string, size int) *Validator {
+	tb.Helper()
+
+	schemaPath := filepath.Join(tb.TempDir(), "schema.cue")
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		tb.Fatalf("failed to write schema: %v", err)
+	}
+
+	validator, err := NewValidatorWithOptions(schemaPath, "#Config", ValidationOptions{CacheSize: size})
+	if err != nil {
+		tb.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+	return validator
+}
+
+// TestResultCache tests reusing results for identical inputs
+func TestResultCache(t *testing.T) {
+	validator := newCachedTestValidator(t, `#Config: {name: string, port: int & >0}`, 2)
+
+	validate := func(name, data string) ValidationResult {
+		t.Helper()
+		result, err := validator.Validate(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(data),
+			Format:     FormatJSON,
+			Name:       name,
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		return result
+	}
+
+	first := validate("a.json", `{"name": "app", "port": 0}`)
+	if first.Valid || len(validator.cache.entries) != 1 {
+		t.Fatalf("expected an invalid, cached result, got %+v", first)
+	}
+
+	// A hit keeps the errors but takes the new name
+	first.Errors[0].Message = "modified by caller"
+	second := validate("b.json", `{"name": "app", "port": 0}`)
+	if second.Name != "b.json" || second.Valid || second.Errors[0].Message == "modified by caller" {
+		t.Errorf("unexpected cached result: %+v", second)
+	}
+	if len(validator.cache.entries) != 1 {
+		t.Errorf("cache has %d entries, want 1", len(validator.cache.entries))
+	}
+
+	// The least recently used result is evicted
+	validate("c.json", `{"name": "app", "port": 1}`)
+	validate("d.json", `{"name": "app", "port": 2}`)
+	if len(validator.cache.entries) != 2 {
+		t.Errorf("cache has %d entries, want 2", len(validator.cache.entries))
+	}
+	key := resultCacheKeyFor(ValidationInput{}, FormatJSON, []byte(`{"name": "app", "port": 0}`))
+	if _, ok := validator.cache.get(key); ok {
+		t.Error("oldest result was not evicted")
+	}
+
+	// Settings that change validation are part of the key
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "app", "port": 1}`),
+		Format:     FormatJSON,
+		Name:       "e.json",
+		InputPath:  "missing",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("a cached result was returned for a different InputPath")
+	}
+}
+
+// TestResultCacheNames tests that results naming their input are not reused
+// for another input with the same bytes
+func TestResultCacheNames(t *testing.T) {
+	validator := newCachedTestValidator(t, `#Config: {name: string}`, 4)
+
+	for _, name := range []string{"a.json", "b.json"} {
+		result, err := validator.Validate(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(`{"name": `),
+			Format:     FormatJSON,
+			Name:       name,
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if !result.ParseFailed || !strings.Contains(result.Errors[0].Message, name) {
+			t.Errorf("%s: errors = %v, want a parse failure naming the input", name, result.Errors)
+		}
+	}
+	if len(validator.cache.entries) != 0 {
+		t.Errorf("cache has %d entries, want parse failures left out", len(validator.cache.entries))
+	}
+}
+
+// benchmarkValidate validates the same payload repeatedly
+func benchmarkValidate(b *testing.B, cacheSize int) {
+	schema := `#Config: {
+	name: string
+	replicas: int & >=1 & <=100
+	labels: [string]: string
+	containers: [...{name: string, image: string, port?: int & >0 & <65536}]
+}`
+	validator := newCachedTestValidator(b, schema, cacheSize)
+
+	data := `{"name": "app", "replicas": 3, "labels": {"tier": "web"}, "containers": [`
+	for i := range 50 {
+		if i > 0 {
+			data += ","
+		}
+		data += fmt.Sprintf(`{"name": "c%d", "image": "nginx:1.%d", "port": %d}`, i, i, 8000+i)
+	}
+	data += "]}"
+
+	input := ValidationInput{SourceType: SourceBytes, Data: []byte(data), Format: FormatJSON, Name: "config.json"}
+	b.ResetTimer()
+	for range b.N {
+		if _, err := validator.Validate(input); err != nil {
+			b.Fatalf("Validate failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkValidateUncached measures re-validating an identical payload
+func BenchmarkValidateUncached(b *testing.B) {
+	benchmarkValidate(b, 0)
+}
+
+// BenchmarkValidateCached measures re-validating an identical payload with a result cache
+func BenchmarkValidateCached(b *testing.B) {
+	benchmarkValidate(b, 16)
+}
@@ -0,0 +1,113 @@
+package cuebridge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"cuelang.org/go/cue"
+)
+
+// Canonicalize validates input and, on success, returns it re-encoded in a
+// canonical form suitable for storage, so that equivalent configs produce
+// identical bytes:
+//   - Schema defaults are applied to fields the input omits
+//   - Object keys are sorted by byte-wise comparison of their names,
+//     at every level; list elements keep their order
+//   - The value is encoded in the input's (resolved) format with the
+//     indentation from ValidationOptions.Export
+//
+// The output is stable across runs. The data is nil when validation fails.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) Canonicalize(input ValidationInput) (ValidationResult, []byte, error) {
+	result, parsed, unified, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, nil, err
+	}
+
+	opts := v.options.Export
+	opts.SortKeys = true
+	data, err := exportValue(unified, parsed.format, opts, parsed.source.data)
+	if err != nil {
+		return ValidationResult{}, nil, err
+	}
+
+	return result, data, nil
+}
+
+// ValidateAndHash validates input and, on success, returns the hex-encoded
+// SHA-256 of its canonical value: the value with schema defaults applied,
+// encoded as JSON with sorted keys. Inputs that differ only in formatting,
+// key order, or format (JSON or YAML) hash the same, as does an input that
+// spells out a default the other omits. The hash does not depend on
+// ValidationOptions.Export.
+//
+// The hash is empty when validation fails.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateAndHash(input ValidationInput) (ValidationResult, string, error) {
+	result, _, unified, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, "", err
+	}
+
+	data, err := exportValue(unified, FormatJSON, ExportOptions{SortKeys: true}, nil)
+	if err != nil {
+		return ValidationResult{}, "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return result, hex.EncodeToString(sum[:]), nil
+}
+
+// ValidateValue validates input and returns the unified value: the input
+// unified with the definition, with defaults applied, for further CUE
+// operations (e.g., LookupPath queries or unifying more constraints)
+// without parsing the input again. The value is returned even when
+// validation fails, in which case it may contain errors (see cue.Value.Err).
+//
+// The value belongs to the validator's cue.Context, which every input of
+// the validator shares. Values combined with it (e.g., by Unify) must come
+// from the same context, such as value.Context().CompileString(...);
+// mixing values from different contexts panics.
+//
+// The value is zero (not Exists) when the input cannot be parsed.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateValue(input ValidationInput) (ValidationResult, cue.Value, error) {
+	result, _, unified, err := v.validateCanonical(input)
+	return result, unified, err
+}
+
+// validateCanonical validates input like Validate, selecting the definition
+// the same way and logging the outcome, and returns the parsed input and the
+// unified value (the input with defaults applied). Results are not cached,
+// since the unified value is needed.
+func (v *Validator) validateCanonical(input ValidationInput) (ValidationResult, parsedInput, cue.Value, error) {
+	var parsed parsedInput
+	var unified cue.Value
+	result, err := v.validateWith(input, func(input ValidationInput) (ValidationResult, error) {
+		p, failed, err := v.parseInput(input)
+		if err != nil {
+			return ValidationResult{}, err
+		}
+		if failed != nil {
+			return *failed, nil
+		}
+
+		definitionName, configDef, failed, err := v.inputDefinition(input, &p)
+		if err != nil {
+			return ValidationResult{}, err
+		}
+		if failed != nil {
+			return *failed, nil
+		}
+
+		parsed = p
+		data := v.withoutAllowedExtras(configDef, p).value
+		if epsilon := v.floatEpsilon(); epsilon > 0 {
+			data = withSchemaFloats(v.ctx, configDef, data, epsilon)
+		}
+		// Fields accepted by AllowedExtraFields are only left out while checking
+		unified = v.withAllowedExtras(configDef, configDef.Unify(data), p.value)
+		return v.checkInput(definitionName, configDef, p), nil
+	})
+	return result, parsed, unified, err
+}
@@ -0,0 +1,252 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+)
+
+// TestCanonicalize tests canonical re-encoding of valid inputs
+func TestCanonicalize(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+	labels?: [string]: string
+}`)
+
+	tests := []struct {
+		name     string
+		data     string
+		format   DataFormat
+		want     string
+		wantFail bool
+	}{
+		{
+			name:   "json sorted with defaults",
+			data:   `{"name": "app", "labels": {"tier": "web", "app": "api"}}`,
+			format: FormatJSON,
+			want:   "{\n  \"labels\": {\n    \"app\": \"api\",\n    \"tier\": \"web\"\n  },\n  \"name\": \"app\",\n  \"replicas\": 1\n}\n",
+		},
+		{
+			name:   "yaml normalized",
+			data:   "replicas:   3\nname:    app\n",
+			format: FormatYAML,
+			want:   "name: app\nreplicas: 3\n",
+		},
+		{
+			name:     "invalid input",
+			data:     `{"replicas": 2}`,
+			format:   FormatJSON,
+			wantFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, data, err := validator.Canonicalize(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("Canonicalize failed: %v", err)
+			}
+			if tt.wantFail {
+				if result.Valid || data != nil {
+					t.Fatalf("expected invalid result without data, got %v, %q", result, data)
+				}
+				return
+			}
+			if !result.Valid {
+				t.Fatalf("expected valid result, got %v", result.Errors)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Canonicalize output:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestCanonicalizeRevalidates tests that canonical output passes the schema
+// it was produced with, and that the input's definition and path are used
+func TestCanonicalizeRevalidates(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+}
+#Metrics: {
+	ratio:   float
+	total:   int
+	window?: *"1m" | string
+}`)
+
+	tests := []struct {
+		name     string
+		input    ValidationInput
+		wantPart string
+	}{
+		{
+			name:     "definition",
+			input:    ValidationInput{Data: []byte(`{"total": 12345678901234567890, "ratio": 1.0}`), Format: FormatJSON, Definition: "#Metrics"},
+			wantPart: `"ratio": 1.0`,
+		},
+		{
+			name:     "definition yaml",
+			input:    ValidationInput{Data: []byte("ratio: 1.0\ntotal: 3\n"), Format: FormatYAML, Definition: "#Metrics"},
+			wantPart: "ratio: 1.0",
+		},
+		{
+			name:     "input path",
+			input:    ValidationInput{Data: []byte(`{"version": 2, "app": {"name": "api"}}`), Format: FormatJSON, InputPath: "app"},
+			wantPart: `"replicas": 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.SourceType = SourceBytes
+			tt.input.Name = "config"
+			result, data, err := validator.Canonicalize(tt.input)
+			if err != nil {
+				t.Fatalf("Canonicalize failed: %v", err)
+			}
+			if !result.Valid {
+				t.Fatalf("expected valid result, got %v", result.Errors)
+			}
+			if !strings.Contains(string(data), tt.wantPart) {
+				t.Errorf("Canonicalize output:\n%s\nwant it to contain %q", data, tt.wantPart)
+			}
+
+			revalidated := tt.input
+			revalidated.Data = data
+			result, err = validator.Validate(revalidated)
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if !result.Valid {
+				t.Errorf("canonical output is invalid: %v\n%s", result.Errors, data)
+			}
+		})
+	}
+}
+
+// TestValidateAndHash tests hashing canonical values
+func TestValidateAndHash(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+	labels?: [string]: string
+}`)
+
+	hash := func(data string, format DataFormat) (ValidationResult, string) {
+		t.Helper()
+		result, sum, err := validator.ValidateAndHash(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(data),
+			Format:     format,
+			Name:       "config",
+		})
+		if err != nil {
+			t.Fatalf("ValidateAndHash failed: %v", err)
+		}
+		return result, sum
+	}
+
+	_, base := hash(`{"name": "app", "labels": {"a": "1", "b": "2"}}`, FormatJSON)
+	if len(base) != 64 {
+		t.Fatalf("hash = %q, want 64 hex digits", base)
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		format   DataFormat
+		wantSame bool
+	}{
+		{name: "reformatted", data: "{\n\t\"name\":\"app\",\n\t\"labels\":{\"a\":\"1\",\"b\":\"2\"}\n}", format: FormatJSON, wantSame: true},
+		{name: "reordered keys", data: `{"labels": {"b": "2", "a": "1"}, "name": "app"}`, format: FormatJSON, wantSame: true},
+		{name: "yaml", data: "labels:\n  b: \"2\"\n  a: \"1\"\nname: app\n", format: FormatYAML, wantSame: true},
+		{name: "explicit default", data: `{"name": "app", "replicas": 1, "labels": {"a": "1", "b": "2"}}`, format: FormatJSON, wantSame: true},
+		{name: "changed value", data: `{"name": "app", "replicas": 2, "labels": {"a": "1", "b": "2"}}`, format: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, sum := hash(tt.data, tt.format)
+			if !result.Valid {
+				t.Fatalf("expected valid result, got %v", result.Errors)
+			}
+			if (sum == base) != tt.wantSame {
+				t.Errorf("hash %s, base %s, want same = %v", sum, base, tt.wantSame)
+			}
+		})
+	}
+
+	if result, sum := hash(`{"replicas": 2}`, FormatJSON); result.Valid || sum != "" {
+		t.Errorf("expected invalid result without hash, got %v, %q", result.Valid, sum)
+	}
+}
+
+// TestValidateValue tests returning the unified value for further CUE operations
+func TestValidateValue(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+}`)
+
+	result, value, err := validator.ValidateValue(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "app"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("ValidateValue failed: %v", err)
+	}
+	if !result.Valid {
+		t.Fatalf("expected valid result, got errors: %v", result.Errors)
+	}
+
+	replicas, err := value.LookupPath(cue.ParsePath("replicas")).Int64()
+	if err != nil || replicas != 1 {
+		t.Errorf("replicas = %d (%v), want default 1", replicas, err)
+	}
+
+	// Further unification uses the value's context
+	extra := value.Context().CompileString(`{replicas: >2}`)
+	if err := value.Unify(extra).Validate(cue.Concrete(true)); err == nil {
+		t.Error("expected unifying replicas: >2 to fail")
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		result, value, err := validator.ValidateValue(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(`{"name": 1}`),
+			Format:     FormatJSON,
+			Name:       "config.json",
+		})
+		if err != nil {
+			t.Fatalf("ValidateValue failed: %v", err)
+		}
+		if result.Valid || !value.Exists() || value.Validate() == nil {
+			t.Errorf("Valid = %v, value exists = %v, want an invalid result with a failing value", result.Valid, value.Exists())
+		}
+	})
+
+	t.Run("unparsable input", func(t *testing.T) {
+		result, value, err := validator.ValidateValue(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(`{"name":`),
+			Format:     FormatJSON,
+			Name:       "config.json",
+		})
+		if err != nil {
+			t.Fatalf("ValidateValue failed: %v", err)
+		}
+		if result.Valid || value.Exists() {
+			t.Errorf("Valid = %v, value exists = %v, want an invalid result and no value", result.Valid, value.Exists())
+		}
+	})
+}
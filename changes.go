@@ -0,0 +1,210 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+)
+
+// ChangeKind describes how a field differs between two versions of a config
+type ChangeKind string
+
+const (
+	// ChangeAdded marks a field only the new version has
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved marks a field only the previous version has
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified marks a field whose value differs
+	ChangeModified ChangeKind = "modified"
+)
+
+// FieldChange is a difference between two versions of a config
+type FieldChange struct {
+	// Path is the changed field's path (e.g., "spec.replicas" or "ports.1")
+	Path string `json:"path"`
+	// Kind is how the field changed
+	Kind ChangeKind `json:"kind"`
+	// Old is the previous value as compact JSON, or empty if it was added
+	Old string `json:"old,omitempty"`
+	// New is the new value as compact JSON, or empty if it was removed
+	New string `json:"new,omitempty"`
+}
+
+// ValidateAndCompare validates input and, on success, compares it with
+// previous, e.g. the last version known to be good, listing the changed
+// fields for review. previous is read like the input (decoding its
+// Encoding and byte order mark, and normalizing line endings unless
+// ValidationOptions.KeepLineEndings is set) and parsed in the input's
+// (resolved) format.
+// Both versions are compared as written, without schema defaults: structs
+// field by field and lists element by element, reporting a whole value
+// when its kind changes (e.g., from a scalar to a struct).
+//
+// The changes are nil when validation fails, and empty when nothing
+// changed. Returns an error if the validation process fails or previous
+// cannot be read or parsed.
+func (v *Validator) ValidateAndCompare(input ValidationInput, previous []byte) (ValidationResult, []FieldChange, error) {
+	result, parsed, _, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, nil, err
+	}
+
+	previousData, err := v.readInput(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       previous,
+		Encoding:   input.Encoding,
+		Name:       input.Name,
+	})
+	if err != nil {
+		return ValidationResult{}, nil, fmt.Errorf("reading previous version: %w", err)
+	}
+	previousValue, err := parseData(v.ctx, previousData, parsed.format, input.Name, parseOptions{
+		proto3JSON: input.Proto3JSON,
+		yamlTags:   v.options.YAMLTagHandlers,
+		keyCase:    v.options.KeyCaseNormalization,
+	})
+	if err != nil {
+		return ValidationResult{}, nil, fmt.Errorf("parsing previous version: %w", err)
+	}
+	if previousValue.Err() != nil {
+		return ValidationResult{}, nil, fmt.Errorf("parsing previous version: %w", previousValue.Err())
+	}
+	if err := newInputLimits(v.options).check(previousValue); err != nil {
+		return ValidationResult{}, nil, fmt.Errorf("previous version: %w", err)
+	}
+
+	changes := []FieldChange{}
+	if err := compareValues(previousValue, parsed.value, nil, &changes); err != nil {
+		return ValidationResult{}, nil, err
+	}
+	return result, changes, nil
+}
+
+// compareValues appends the differences between old and new at path
+func compareValues(old, new cue.Value, path []string, changes *[]FieldChange) error {
+	oldKind, newKind := old.IncompleteKind(), new.IncompleteKind()
+	switch {
+	case oldKind == cue.StructKind && newKind == cue.StructKind:
+		return compareStructs(old, new, path, changes)
+	case oldKind == cue.ListKind && newKind == cue.ListKind:
+		return compareLists(old, new, path, changes)
+	}
+
+	oldJSON, err := compactJSON(old)
+	if err != nil {
+		return err
+	}
+	newJSON, err := compactJSON(new)
+	if err != nil {
+		return err
+	}
+	if oldJSON != newJSON {
+		*changes = append(*changes, FieldChange{Path: formatPath(path), Kind: ChangeModified, Old: oldJSON, New: newJSON})
+	}
+	return nil
+}
+
+// compareStructs compares the fields of two structs: fields of old in
+// order, then the fields only new has
+func compareStructs(old, new cue.Value, path []string, changes *[]FieldChange) error {
+	oldFields, err := old.Fields()
+	if err != nil {
+		return err
+	}
+	for oldFields.Next() {
+		sel := oldFields.Selector()
+		fieldPath := append(path[:len(path):len(path)], sel.String())
+		newField := new.LookupPath(cue.MakePath(sel))
+		if !newField.Exists() {
+			if err := appendChange(changes, ChangeRemoved, fieldPath, oldFields.Value()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := compareValues(oldFields.Value(), newField, fieldPath, changes); err != nil {
+			return err
+		}
+	}
+
+	newFields, err := new.Fields()
+	if err != nil {
+		return err
+	}
+	for newFields.Next() {
+		sel := newFields.Selector()
+		if old.LookupPath(cue.MakePath(sel)).Exists() {
+			continue
+		}
+		if err := appendChange(changes, ChangeAdded, append(path[:len(path):len(path)], sel.String()), newFields.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareLists compares two lists by index; elements past the end of the
+// shorter list are added or removed
+func compareLists(old, new cue.Value, path []string, changes *[]FieldChange) error {
+	oldElems, err := listElements(old)
+	if err != nil {
+		return err
+	}
+	newElems, err := listElements(new)
+	if err != nil {
+		return err
+	}
+
+	for i := range max(len(oldElems), len(newElems)) {
+		elemPath := append(path[:len(path):len(path)], fmt.Sprint(i))
+		switch {
+		case i >= len(newElems):
+			err = appendChange(changes, ChangeRemoved, elemPath, oldElems[i])
+		case i >= len(oldElems):
+			err = appendChange(changes, ChangeAdded, elemPath, newElems[i])
+		default:
+			err = compareValues(oldElems[i], newElems[i], elemPath, changes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listElements returns the elements of a list value
+func listElements(list cue.Value) ([]cue.Value, error) {
+	iter, err := list.List()
+	if err != nil {
+		return nil, err
+	}
+	var elems []cue.Value
+	for iter.Next() {
+		elems = append(elems, iter.Value())
+	}
+	return elems, nil
+}
+
+// appendChange appends an added or removed value at path
+func appendChange(changes *[]FieldChange, kind ChangeKind, path []string, value cue.Value) error {
+	data, err := compactJSON(value)
+	if err != nil {
+		return err
+	}
+	change := FieldChange{Path: formatPath(path), Kind: kind}
+	if kind == ChangeRemoved {
+		change.Old = data
+	} else {
+		change.New = data
+	}
+	*changes = append(*changes, change)
+	return nil
+}
+
+// compactJSON encodes a value as compact JSON
+func compactJSON(value cue.Value) (string, error) {
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("encoding %s: %w", value.Path(), err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,111 @@
+package cuebridge
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+// TestValidateAndCompare tests listing the fields changed since a previous version
+func TestValidateAndCompare(t *testing.T) {
+	schema := `
+#Config: {
+	name:     string
+	replicas: *1 | int
+	ports: [...int]
+	labels?: [string]: string
+	tls?: bool | {cert: string}
+}
+`
+	validator := newTestValidator(t, schema)
+	previous := `{"name": "app", "ports": [80, 443], "labels": {"team": "web"}, "tls": true}`
+
+	tests := []struct {
+		name        string
+		data        string
+		previous    string
+		encoding    InputEncoding
+		wantValid   bool
+		wantChanges []FieldChange
+		wantErr     bool
+	}{
+		{
+			name:        "unchanged",
+			data:        previous,
+			previous:    previous,
+			wantValid:   true,
+			wantChanges: []FieldChange{},
+		},
+		{
+			name:      "added, removed, and modified fields",
+			data:      `{"name": "api", "replicas": 3, "ports": [80], "tls": {"cert": "a.pem"}}`,
+			previous:  previous,
+			wantValid: true,
+			wantChanges: []FieldChange{
+				{Path: "name", Kind: ChangeModified, Old: `"app"`, New: `"api"`},
+				{Path: "ports.1", Kind: ChangeRemoved, Old: "443"},
+				{Path: "labels", Kind: ChangeRemoved, Old: `{"team":"web"}`},
+				{Path: "tls", Kind: ChangeModified, Old: "true", New: `{"cert":"a.pem"}`},
+				{Path: "replicas", Kind: ChangeAdded, New: "3"},
+			},
+		},
+		{
+			name:      "nested and list additions",
+			data:      `{"name": "app", "ports": [80, 443, 8080], "labels": {"team": "web", "tier": "front"}, "tls": true}`,
+			previous:  previous,
+			wantValid: true,
+			wantChanges: []FieldChange{
+				{Path: "ports.2", Kind: ChangeAdded, New: "8080"},
+				{Path: "labels.tier", Kind: ChangeAdded, New: `"front"`},
+			},
+		},
+		{
+			name:      "invalid input is not compared",
+			data:      `{"name": 1, "ports": []}`,
+			previous:  previous,
+			wantValid: false,
+		},
+		{
+			name:      "previous read with the input's encoding",
+			data:      base64.StdEncoding.EncodeToString([]byte(`{"name": "api", "ports": [80, 443], "labels": {"team": "web"}, "tls": true}`)),
+			previous:  base64.StdEncoding.EncodeToString([]byte("\uFEFF" + previous)),
+			encoding:  EncodingBase64,
+			wantValid: true,
+			wantChanges: []FieldChange{
+				{Path: "name", Kind: ChangeModified, Old: `"app"`, New: `"api"`},
+			},
+		},
+		{
+			name:     "unparsable previous version",
+			data:     previous,
+			previous: `{"name": `,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, changes, err := validator.ValidateAndCompare(ValidationInput{
+				Name:       "config.json",
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Encoding:   tt.encoding,
+			}, []byte(tt.previous))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ValidateAndCompare() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAndCompare() error = %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if !reflect.DeepEqual(changes, tt.wantChanges) {
+				t.Errorf("changes = %+v, want %+v", changes, tt.wantChanges)
+			}
+		})
+	}
+}
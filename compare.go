@@ -0,0 +1,78 @@
+package cuebridge
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ResultsEqual reports whether two results are semantically equal, e.g. for
+// asserting expected results in tests. It compares Name, Valid, Definition,
+// and the errors in any order by Line, Column, Path, Message, and Severity.
+// SchemaPath, which depends on where the schema lives, error fields
+// derived from the input (Pointer, GotType, SourceLine), and RawMessage,
+// the unrewritten Message, are ignored.
+func ResultsEqual(a, b ValidationResult) bool {
+	return DiffResults(a, b) == ""
+}
+
+// DiffResults describes the differences between two results as compared by
+// ResultsEqual, one per line, or returns "" if they are equal. Errors only
+// in a are prefixed with "-", errors only in b with "+".
+func DiffResults(a, b ValidationResult) string {
+	var diff strings.Builder
+	if a.Name != b.Name {
+		fmt.Fprintf(&diff, "name: %q != %q\n", a.Name, b.Name)
+	}
+	if a.Valid != b.Valid {
+		fmt.Fprintf(&diff, "valid: %v != %v\n", a.Valid, b.Valid)
+	}
+	if a.Definition != b.Definition {
+		fmt.Fprintf(&diff, "definition: %q != %q\n", a.Definition, b.Definition)
+	}
+
+	aErrors, bErrors := comparableErrors(a.Errors), comparableErrors(b.Errors)
+	for i, j := 0, 0; i < len(aErrors) || j < len(bErrors); {
+		switch {
+		case j == len(bErrors) || (i < len(aErrors) && compareErrors(aErrors[i], bErrors[j]) < 0):
+			fmt.Fprintf(&diff, "- %s: %v\n", aErrors[i].Severity, aErrors[i])
+			i++
+		case i == len(aErrors) || compareErrors(aErrors[i], bErrors[j]) > 0:
+			fmt.Fprintf(&diff, "+ %s: %v\n", bErrors[j].Severity, bErrors[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	return diff.String()
+}
+
+// comparableErrors returns errors reduced to the compared fields, sorted
+func comparableErrors(errs []ValidationError) []ValidationError {
+	compared := make([]ValidationError, len(errs))
+	for i, e := range errs {
+		compared[i] = ValidationError{
+			Line:     e.Line,
+			Column:   e.Column,
+			Path:     e.Path,
+			Message:  e.Message,
+			Severity: e.Severity,
+		}
+	}
+	slices.SortFunc(compared, compareErrors)
+	return compared
+}
+
+// compareErrors orders errors by position, path, message, and severity
+func compareErrors(a, b ValidationError) int {
+	return cmp.Or(
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Column, b.Column),
+		cmp.Compare(a.Path, b.Path),
+		cmp.Compare(a.Message, b.Message),
+		cmp.Compare(a.Severity, b.Severity),
+	)
+}
@@ -0,0 +1,75 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestResultsEqual tests semantic comparison of results
+func TestResultsEqual(t *testing.T) {
+	portErr := ValidationError{Line: 3, Column: 7, Path: "port", Message: "invalid value 0"}
+	nameErr := ValidationError{Line: 1, Column: 1, Path: "name", Message: "incomplete value string"}
+	base := ValidationResult{
+		Name:       "config.yaml",
+		Valid:      false,
+		Errors:     []ValidationError{portErr, nameErr},
+		SchemaPath: "/tmp/a/schema.cue",
+		Definition: "#Config",
+	}
+
+	tests := []struct {
+		name      string
+		other     ValidationResult
+		wantEqual bool
+		wantDiff  []string
+	}{
+		{
+			name: "errors reordered, volatile fields differ",
+			other: ValidationResult{
+				Name:       "config.yaml",
+				Valid:      false,
+				Errors:     []ValidationError{nameErr, {Line: 3, Column: 7, Path: "port", Message: "invalid value 0", SourceLine: "port: 0"}},
+				SchemaPath: "/tmp/b/schema.cue",
+				Definition: "#Config",
+			},
+			wantEqual: true,
+		},
+		{
+			name: "different errors",
+			other: ValidationResult{
+				Name:       "config.yaml",
+				Valid:      false,
+				Errors:     []ValidationError{portErr, {Path: "extra", Message: "field not allowed"}},
+				Definition: "#Config",
+			},
+			wantEqual: false,
+			wantDiff: []string{
+				`+ error: field "extra": field not allowed`,
+				`- error: line 1, field "name": incomplete value string`,
+			},
+		},
+		{
+			name:      "different validity",
+			other:     ValidationResult{Name: "config.yaml", Valid: true, Errors: []ValidationError{}, Definition: "#Config"},
+			wantEqual: false,
+			wantDiff:  []string{"valid: false != true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResultsEqual(base, tt.other); got != tt.wantEqual {
+				t.Errorf("ResultsEqual = %v, want %v", got, tt.wantEqual)
+			}
+			diff := DiffResults(base, tt.other)
+			if tt.wantEqual && diff != "" {
+				t.Errorf("DiffResults = %q, want empty", diff)
+			}
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(diff, want) {
+					t.Errorf("DiffResults = %q, want it to contain %q", diff, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+)
+
+// CompatibleWith reports whether the definition in newSchemaPath is
+// backward-compatible with the same definition in oldSchemaPath.
+//
+// The new schema is compatible when it subsumes the old one: every value
+// that is valid under the old definition is also valid under the new one.
+// Loosening a constraint or adding an optional field, or one with a
+// concrete value or default, is compatible; tightening a constraint,
+// adding a field that inputs must set (field! or a regular field without
+// a default), making an optional field required, removing a field from a
+// closed definition, or closing a struct that accepted other fields
+// (e.g., one ending in "...") is not.
+//
+// When incompatible, the returned reasons describe each violation by
+// field name.
+// Returns an error if either schema cannot be loaded or lacks the definition.
+func CompatibleWith(oldSchemaPath, newSchemaPath, definitionName string) (bool, []string, error) {
+	ctx := cuecontext.New()
+
+	oldDef, err := compileDefinition(ctx, oldSchemaPath, definitionName)
+	if err != nil {
+		return false, nil, fmt.Errorf("old schema: %w", err)
+	}
+	newDef, err := compileDefinition(ctx, newSchemaPath, definitionName)
+	if err != nil {
+		return false, nil, fmt.Errorf("new schema: %w", err)
+	}
+
+	// Subsumption ignores the required marker (field!) and reports new
+	// regular fields only as "regular field is constraint in subsumed
+	// value", even when they are concrete or have a default, so fields
+	// that inputs must now supply are found separately
+	required, requiredPaths := requiredFieldReasons(oldDef, newDef, nil)
+
+	var reasons []string
+	errs := errors.Errors(newDef.Subsume(oldDef))
+	for _, e := range errs {
+		if reason, ok := subsumeReason(e, requiredPaths); ok {
+			reasons = append(reasons, reason)
+		}
+	}
+	// Errors other than new regular fields may all have been left out
+	// without any field being flagged; the change must still be reported
+	if len(reasons) == 0 && len(required) == 0 && len(errs) > 0 && !onlySummaries(errs) {
+		reasons = append(reasons, "the new definition does not accept every value the old one does")
+	}
+	reasons = append(reasons, required...)
+	// Subsumption does not compare closedness, so a struct closed in the
+	// new definition would otherwise pass
+	reasons = append(reasons, closednessReasons(oldDef, newDef, nil)...)
+	return len(reasons) == 0, reasons, nil
+}
+
+// subsumeReason rewrites a subsumption error in terms of fields, or
+// reports false if the error carries no information of its own. CUE
+// names only the last element of the field's path.
+func subsumeReason(e errors.Error, requiredPaths map[string]bool) (string, bool) {
+	format, args := e.Msg()
+	name := ""
+	if len(args) > 0 {
+		name = fmt.Sprint(args[0])
+	}
+	switch format {
+	case "regular field is constraint in subsumed value: %v", "field %v not present in %v", "value not an instance":
+		// New fields are judged by requiredFieldReasons; the others
+		// repeat a "missing field" error
+		return "", false
+	case "missing field %q":
+		for path := range requiredPaths {
+			if path == name || strings.HasPrefix(path, name+".") {
+				return "", false
+			}
+		}
+		return fmt.Sprintf("%s no longer accepts every value it accepted before", name), true
+	case "field not allowed in closed struct: %v":
+		return fmt.Sprintf("%s is no longer allowed", name), true
+	default:
+		return e.Error(), true
+	}
+}
+
+// onlySummaries reports whether errs hold nothing but the errors
+// subsumeReason leaves out because requiredFieldReasons covers them
+func onlySummaries(errs []errors.Error) bool {
+	for _, e := range errs {
+		format, _ := e.Msg()
+		if format != "regular field is constraint in subsumed value: %v" && format != "value not an instance" {
+			return false
+		}
+	}
+	return true
+}
+
+// requiredFieldReasons describes each field that inputs must supply under
+// newDef but not under oldDef, at path or within it, and returns the
+// paths of those fields. A field must be supplied if it is required
+// (field!) or regular without a concrete value or default.
+func requiredFieldReasons(oldDef, newDef cue.Value, path []string) ([]string, map[string]bool) {
+	reasons, paths := []string{}, map[string]bool{}
+	collectRequiredFieldReasons(oldDef, newDef, path, &reasons, paths)
+	return reasons, paths
+}
+
+// collectRequiredFieldReasons appends the reasons and paths of
+// requiredFieldReasons
+func collectRequiredFieldReasons(oldDef, newDef cue.Value, path []string, reasons *[]string, paths map[string]bool) {
+	switch newDef.IncompleteKind() {
+	case cue.StructKind:
+		if oldDef.IncompleteKind() != cue.StructKind {
+			return
+		}
+		oldFields := map[string]cue.Value{}
+		oldRequired := map[string]bool{}
+		iter, err := oldDef.Fields(cue.Optional(true))
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			name := iter.Selector().Unquoted()
+			oldFields[name] = iter.Value()
+			oldRequired[name] = mustSupply(iter.Selector(), iter.Value())
+		}
+
+		iter, err = newDef.Fields(cue.Optional(true))
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			sel := iter.Selector()
+			fieldPath := append(path[:len(path):len(path)], sel.Unquoted())
+			oldField, existed := oldFields[sel.Unquoted()]
+			if mustSupply(sel, iter.Value()) && !oldRequired[sel.Unquoted()] {
+				location := formatPath(fieldPath)
+				paths[location] = true
+				if existed {
+					*reasons = append(*reasons, fmt.Sprintf("%s must now be set but was optional before", location))
+				} else {
+					*reasons = append(*reasons, fmt.Sprintf("%s is a new field that must be set", location))
+				}
+			}
+			if existed {
+				collectRequiredFieldReasons(oldField, iter.Value(), fieldPath, reasons, paths)
+			}
+		}
+
+	case cue.ListKind:
+		oldElement := oldDef.LookupPath(cue.MakePath(cue.AnyIndex))
+		newElement := newDef.LookupPath(cue.MakePath(cue.AnyIndex))
+		if oldElement.Exists() && newElement.Exists() {
+			collectRequiredFieldReasons(oldElement, newElement, append(path[:len(path):len(path)], "*"), reasons, paths)
+		}
+	}
+}
+
+// mustSupply reports whether an input must set the field sel with the
+// schema value: it is required, or regular without a concrete value
+// (e.g., "name: string" but not "replicas: *1 | int")
+func mustSupply(sel cue.Selector, value cue.Value) bool {
+	switch sel.ConstraintType() {
+	case cue.RequiredConstraint:
+		return true
+	case cue.OptionalConstraint:
+		return false
+	default:
+		value, _ = value.Default()
+		return value.Validate(cue.Concrete(true)) != nil
+	}
+}
+
+// closednessReasons describes each struct that allows fields it does not
+// declare (e.g., through "..." or [string]: _) in oldDef but not in
+// newDef, at path or within it
+func closednessReasons(oldDef, newDef cue.Value, path []string) []string {
+	switch oldDef.IncompleteKind() {
+	case cue.StructKind:
+		if newDef.IncompleteKind() != cue.StructKind {
+			return nil
+		}
+		if oldDef.Allows(cue.AnyString) && !newDef.Allows(cue.AnyString) {
+			location := "the definition"
+			if len(path) > 0 {
+				location = formatPath(path)
+			}
+			return []string{fmt.Sprintf("%s is closed but allowed other fields before", location)}
+		}
+
+		var reasons []string
+		iter, err := oldDef.Fields(cue.Optional(true))
+		if err != nil {
+			return nil
+		}
+		for iter.Next() {
+			sel := iter.Selector()
+			newField := lookupSchemaField(newDef, cue.Str(sel.Unquoted()))
+			if newField.Exists() {
+				reasons = append(reasons, closednessReasons(iter.Value(), newField, append(path[:len(path):len(path)], sel.Unquoted()))...)
+			}
+		}
+		return reasons
+
+	case cue.ListKind:
+		oldElement := oldDef.LookupPath(cue.MakePath(cue.AnyIndex))
+		newElement := newDef.LookupPath(cue.MakePath(cue.AnyIndex))
+		if !oldElement.Exists() || !newElement.Exists() {
+			return nil
+		}
+		return closednessReasons(oldElement, newElement, append(path[:len(path):len(path)], "*"))
+
+	default:
+		return nil
+	}
+}
+
+// compileDefinition compiles a schema file and looks up a definition in it
+func compileDefinition(ctx *cue.Context, schemaPath string, definitionName string) (cue.Value, error) {
+	schema, err := compileSchemaFile(ctx, schemaPath, ValidationOptions{})
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	configDef := schema.LookupPath(cue.ParsePath(definitionName))
+	if !configDef.Exists() {
+		return cue.Value{}, fmt.Errorf("schema does not define %s", definitionName)
+	}
+	return configDef, nil
+}
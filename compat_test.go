@@ -0,0 +1,123 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestCompatibleWith tests schema evolution checks
+func TestCompatibleWith(t *testing.T) {
+	defaultOldSchema := `#Config: {name: string, replicas: int & <=10}`
+
+	tests := []struct {
+		name           string
+		oldSchema      string
+		newSchema      string
+		wantCompatible bool
+		wantReason     string
+	}{
+		{
+			name:           "loosened constraint",
+			newSchema:      `#Config: {name: string, replicas: int & <=20}`,
+			wantCompatible: true,
+		},
+		{
+			name:           "added optional field",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, region?: string}`,
+			wantCompatible: true,
+		},
+		{
+			name:           "tightened constraint",
+			newSchema:      `#Config: {name: string, replicas: int & <=5}`,
+			wantCompatible: false,
+			wantReason:     "replicas no longer accepts every value it accepted before",
+		},
+		{
+			name:           "closed open struct",
+			oldSchema:      `#Config: {name: string, replicas: int & <=10, ...}`,
+			newSchema:      `#Config: {name: string, replicas: int & <=10}`,
+			wantCompatible: false,
+		},
+		{
+			name:           "closed nested struct",
+			oldSchema:      `#Config: {name: string, replicas: int & <=10, labels?: {...}}`,
+			newSchema:      `#Config: {name: string, replicas: int & <=10, labels?: {team?: string}}`,
+			wantCompatible: false,
+		},
+		{
+			name:           "opened struct",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, ...}`,
+			wantCompatible: true,
+		},
+		{
+			name:           "added required field",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, region: string}`,
+			wantCompatible: false,
+			wantReason:     "region is a new field that must be set",
+		},
+		{
+			name:           "added required marker field",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, region!: string}`,
+			wantCompatible: false,
+			wantReason:     "region is a new field that must be set",
+		},
+		{
+			name:           "made optional field required",
+			oldSchema:      `#Config: {name: string, replicas: int & <=10, region?: string}`,
+			newSchema:      `#Config: {name: string, replicas: int & <=10, region!: string}`,
+			wantCompatible: false,
+			wantReason:     "region must now be set but was optional before",
+		},
+		{
+			name:           "added nested required field",
+			oldSchema:      `#Config: {name: string, replicas: int & <=10, labels?: {team?: string}}`,
+			newSchema:      `#Config: {name: string, replicas: int & <=10, labels?: {team?: string, owner!: string}}`,
+			wantCompatible: false,
+			wantReason:     "labels.owner is a new field that must be set",
+		},
+		{
+			name:           "added defaulted field",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, region: *"us" | string}`,
+			wantCompatible: true,
+		},
+		{
+			name:           "added concrete field",
+			newSchema:      `#Config: {name: string, replicas: int & <=10, version: 1}`,
+			wantCompatible: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			oldPath := filepath.Join(tmpDir, "old.cue")
+			newPath := filepath.Join(tmpDir, "new.cue")
+			oldSchema := tt.oldSchema
+			if oldSchema == "" {
+				oldSchema = defaultOldSchema
+			}
+			if err := os.WriteFile(oldPath, []byte(oldSchema), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+			if err := os.WriteFile(newPath, []byte(tt.newSchema), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			compatible, reasons, err := CompatibleWith(oldPath, newPath, "#Config")
+			if err != nil {
+				t.Fatalf("CompatibleWith failed: %v", err)
+			}
+			if compatible != tt.wantCompatible {
+				t.Errorf("compatible = %v, want %v (reasons: %v)", compatible, tt.wantCompatible, reasons)
+			}
+			if !compatible && len(reasons) == 0 {
+				t.Error("expected reasons for incompatibility")
+			}
+			if tt.wantReason != "" && !slices.Contains(reasons, tt.wantReason) {
+				t.Errorf("reasons = %q, want %q among them", reasons, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package cuebridge
+
+import (
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/token"
+)
+
+// annotateConditions sets ValidationError.Condition for each error on a
+// field the schema declares inside if-clauses (e.g., `if type == "gpu" {
+// gpus: >=1 }`), so that users see why the constraint applies. schema
+// holds the files of the compiled schema; fields from other sources, such
+// as imported packages, and fields inside for-clauses are not annotated.
+// errs must have been extracted from err.
+func annotateConditions(schema []*ast.File, unified cue.Value, err error, errs []ValidationError) {
+	cueErrors := errors.Errors(err)
+	if len(schema) == 0 || len(cueErrors) != len(errs) {
+		return
+	}
+
+	for i, e := range cueErrors {
+		if len(e.Path()) == 0 {
+			continue
+		}
+		pos := unified.LookupPath(inputPath(e.Path())).Pos()
+		if !pos.IsValid() {
+			continue
+		}
+		for _, file := range schema {
+			if file.Filename != pos.Filename() {
+				continue
+			}
+			if conditions, ok := enclosingConditions(file, pos); ok && len(conditions) > 0 {
+				errs[i].Condition = strings.Join(conditions, " && ")
+			}
+			break
+		}
+	}
+}
+
+// enclosingConditions returns the conditions of the if-clauses around pos
+// in node, outermost first. It reports false if pos is inside a
+// for-clause, whose condition cannot be stated on its own.
+func enclosingConditions(node ast.Node, pos token.Pos) ([]string, bool) {
+	var conditions []string
+	ok := true
+	ast.Walk(node, func(n ast.Node) bool {
+		if !ok || !containsPos(n, pos) {
+			return false
+		}
+		comprehension, isComprehension := n.(*ast.Comprehension)
+		if !isComprehension {
+			return true
+		}
+		for _, clause := range comprehension.Clauses {
+			ifClause, isIf := clause.(*ast.IfClause)
+			if !isIf {
+				ok = false
+				return false
+			}
+			source, err := format.Node(ifClause.Condition)
+			if err != nil {
+				ok = false
+				return false
+			}
+			conditions = append(conditions, string(source))
+		}
+		return true
+	}, nil)
+	return conditions, ok
+}
+
+// containsPos reports whether the source range of n includes pos
+func containsPos(n ast.Node, pos token.Pos) bool {
+	start, end := n.Pos(), n.End()
+	if !start.IsValid() || !end.IsValid() || start.Filename() != pos.Filename() {
+		// Nodes without positions (e.g., a File's implicit root) may still
+		// contain positioned children
+		return !start.IsValid() || !end.IsValid()
+	}
+	return start.Offset() <= pos.Offset() && pos.Offset() < end.Offset()
+}
@@ -0,0 +1,136 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestConditionAnnotations tests naming the if-clause a failing field depends on
+func TestConditionAnnotations(t *testing.T) {
+	validator := newTestValidator(t, `#Node: {
+	type: "cpu" | "gpu"
+	if type == "gpu" {
+		gpus: int & >=1
+	}
+}
+#Config: {
+	node:  #Node
+	mode:  string
+	name?: string
+	if mode == "prod" {
+		if name != _|_ {
+			replicas: int & >=2
+		}
+	}
+	for k, min in {a: 1} {
+		"item_\(k)"?: int & >min
+	}
+}`)
+
+	tests := []struct {
+		name          string
+		data          string
+		wantPath      string
+		wantCondition string
+		wantError     string
+	}{
+		{
+			name:          "missing conditional field",
+			data:          `{"mode": "dev", "node": {"type": "gpu"}}`,
+			wantPath:      "node.gpus",
+			wantCondition: `type == "gpu"`,
+			wantError:     `field "node.gpus": missing required field (applies when type == "gpu")`,
+		},
+		{
+			name:          "nested conditions",
+			data:          `{"mode": "prod", "name": "app", "node": {"type": "cpu"}, "replicas": 1}`,
+			wantPath:      "replicas",
+			wantCondition: `mode == "prod" && name != _|_`,
+		},
+		{
+			name:     "unconditional field",
+			data:     `{"mode": 1, "node": {"type": "cpu"}}`,
+			wantPath: "mode",
+		},
+		{
+			name:     "for-clause is not annotated",
+			data:     `{"mode": "dev", "node": {"type": "cpu"}, "item_a": 0}`,
+			wantPath: "item_a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("got %d errors, want 1: %v", len(result.Errors), result.Errors)
+			}
+
+			e := result.Errors[0]
+			if e.Path != tt.wantPath || e.Condition != tt.wantCondition {
+				t.Errorf("got path %q condition %q, want %q %q", e.Path, e.Condition, tt.wantPath, tt.wantCondition)
+			}
+			if tt.wantError != "" && e.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", e.Error(), tt.wantError)
+			}
+		})
+	}
+}
+
+// TestConditionAnnotationsSchemaSources tests annotating errors for schemas
+// loaded from a package directory and schemas with overrides
+func TestConditionAnnotationsSchemaSources(t *testing.T) {
+	config := "#Config: {\n\tnode: #Node\n\tmode: string\n}\n"
+	node := "#Node: {\n\ttype: \"cpu\" | \"gpu\"\n\tif type == \"gpu\" {\n\t\tgpus: int & >=1\n\t}\n}\n"
+
+	schemaDir := t.TempDir()
+	files := map[string]string{
+		"config.cue": "package schemas\n\n" + config,
+		"node.cue":   "package schemas\n\n" + node,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(schemaDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schemaPath string
+		opts       ValidationOptions
+	}{
+		{name: "package directory", schemaPath: schemaDir},
+		{name: "overrides", schemaPath: writeTestSchema(t, config+node), opts: ValidationOptions{Overrides: map[string]string{"mode": "dev"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := NewValidatorWithOptions(tt.schemaPath, "#Config", tt.opts)
+			if err != nil {
+				t.Fatalf("NewValidatorWithOptions failed: %v", err)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(`{"mode": "dev", "node": {"type": "gpu", "gpus": 0}}`),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if len(result.Errors) != 1 || result.Errors[0].Condition != `type == "gpu"` {
+				t.Errorf("errors = %+v, want one applying when type == \"gpu\"", result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cuebridge
+
+import (
+	"cuelang.org/go/cue"
+)
+
+// ValidateWithDefaults validates input and, on success, returns the
+// completed config: the input with schema defaults applied and the fields
+// the schema computes filled in (e.g., `fullName: firstName + " " + lastName`),
+// encoded in the input's (resolved) format with ValidationOptions.Export.
+// Fields keep the schema's order; use Canonicalize for sorted keys.
+// Hidden fields and definitions are not exported.
+//
+// The data is nil when validation fails.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateWithDefaults(input ValidationInput) (ValidationResult, []byte, error) {
+	result, parsed, unified, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, nil, err
+	}
+
+	data, err := exportValue(unified, parsed.format, v.options.Export, parsed.source.data)
+	if err != nil {
+		return ValidationResult{}, nil, err
+	}
+	return result, data, nil
+}
+
+// ValidateWithDefaultReport validates input and additionally returns the
+// paths of fields whose values came from schema defaults rather than the
+// input (e.g., "replicas" for `replicas: *1 | int` when the input omits it).
+//
+// Only leaf fields are reported. The paths are nil when validation fails.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateWithDefaultReport(input ValidationInput) (ValidationResult, []string, error) {
+	result, parsed, unified, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, nil, err
+	}
+
+	var defaulted []string
+	for _, path := range defaultedFields(unified, parsed.value) {
+		defaulted = append(defaulted, formatCUEPath(path))
+	}
+	return result, defaulted, nil
+}
+
+// ValidateAndPatch validates input and, when it is valid but omits fields
+// that have schema defaults (e.g., fields added in a new schema version),
+// returns the input with those fields written in, for migrating config
+// files forward. Only defaulted fields are added; other fields, including
+// computed ones, are left as they are. The patched config is encoded in
+// the input's (resolved) format with ValidationOptions.Export, so
+// ExportOptions.PreserveComments keeps YAML comments.
+//
+// patched reports whether any field was added; the data is nil unless it
+// is true. An invalid input (e.g., a type conflict) is returned unpatched.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateAndPatch(input ValidationInput) (ValidationResult, []byte, bool, error) {
+	result, parsed, unified, err := v.validateCanonical(input)
+	if err != nil || !result.Valid {
+		return result, nil, false, err
+	}
+
+	paths := defaultedFields(unified, parsed.value)
+	if len(paths) == 0 {
+		return result, nil, false, nil
+	}
+
+	patched := parsed.value
+	for _, path := range paths {
+		value, _ := unified.LookupPath(path).Default()
+		patched = patched.FillPath(path, value)
+	}
+
+	data, err := exportValue(patched, parsed.format, v.options.Export, parsed.source.data)
+	if err != nil {
+		return ValidationResult{}, nil, false, err
+	}
+	return result, data, true, nil
+}
+
+// defaultedFields returns the paths of leaf fields in unified that are
+// absent from data and have a default value
+func defaultedFields(unified cue.Value, data cue.Value) []cue.Path {
+	var defaulted []cue.Path
+	collectDefaultedFields(unified, data, nil, &defaulted)
+	return defaulted
+}
+
+// collectDefaultedFields appends the paths of leaf fields in unified that are
+// absent from data and have a default value
+func collectDefaultedFields(unified cue.Value, data cue.Value, path []cue.Selector, defaulted *[]cue.Path) {
+	iter, err := unified.Fields()
+	if err != nil {
+		return
+	}
+
+	for iter.Next() {
+		sel := iter.Selector()
+		field := iter.Value()
+		fieldPath := append(path[:len(path):len(path)], sel)
+
+		var dataField cue.Value
+		if data.Exists() {
+			dataField = data.LookupPath(cue.MakePath(sel))
+		}
+
+		if field.IncompleteKind() == cue.StructKind {
+			collectDefaultedFields(field, dataField, fieldPath, defaulted)
+			continue
+		}
+		if dataField.Exists() {
+			continue
+		}
+		if _, hasDefault := field.Default(); hasDefault {
+			*defaulted = append(*defaulted, cue.MakePath(fieldPath...))
+		}
+	}
+}
+
+// formatCUEPath formats a CUE path like formatPath (e.g., "resources.memory")
+func formatCUEPath(path cue.Path) string {
+	var parts []string
+	for _, sel := range path.Selectors() {
+		parts = append(parts, sel.String())
+	}
+	return formatPath(parts)
+}
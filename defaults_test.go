@@ -0,0 +1,233 @@
+package cuebridge
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestValidateWithDefaultReport tests reporting fields filled from defaults
+func TestValidateWithDefaultReport(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+	image:    string | *"nginx"
+	id:       "app-" + name
+	resources: {
+		cpu:    *"100m" | string
+		memory: *"128Mi" | string
+	}
+}`)
+
+	result, defaulted, err := validator.ValidateWithDefaultReport(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nimage: busybox\nresources:\n  cpu: 500m\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("ValidateWithDefaultReport failed: %v", err)
+	}
+	if !result.Valid {
+		t.Fatalf("expected valid result, got %v", result.Errors)
+	}
+
+	want := []string{"replicas", "resources.memory"}
+	if !slices.Equal(defaulted, want) {
+		t.Errorf("defaulted = %v, want %v", defaulted, want)
+	}
+}
+
+// TestValidateWithDefaults tests exporting defaults and computed fields
+func TestValidateWithDefaults(t *testing.T) {
+	validator := newTestValidator(t, `import "strings"
+
+#Config: {
+	firstName: string
+	lastName:  string
+	fullName:  firstName + " " + lastName
+	slug:      strings.ToLower(lastName)
+	ports: [...int]
+	portCount: len(ports)
+	replicas:  *1 | int
+	_internal: "hidden"
+}`)
+
+	tests := []struct {
+		name   string
+		data   string
+		format DataFormat
+		want   string
+	}{
+		{
+			name:   "yaml",
+			data:   "lastName: Lovelace\nfirstName: Ada\nports: [80]\n",
+			format: FormatYAML,
+			want:   "firstName: Ada\nlastName: Lovelace\nfullName: Ada Lovelace\nslug: lovelace\nports:\n  - 80\nportCount: 1\nreplicas: 1\n",
+		},
+		{
+			name:   "json",
+			data:   `{"firstName": "Ada", "lastName": "Lovelace", "ports": [], "replicas": 3}`,
+			format: FormatJSON,
+			want:   "{\n  \"firstName\": \"Ada\",\n  \"lastName\": \"Lovelace\",\n  \"fullName\": \"Ada Lovelace\",\n  \"slug\": \"lovelace\",\n  \"ports\": [],\n  \"portCount\": 0,\n  \"replicas\": 3\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, data, err := validator.ValidateWithDefaults(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("ValidateWithDefaults failed: %v", err)
+			}
+			if !result.Valid {
+				t.Fatalf("expected valid result, got %v", result.Errors)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+
+	result, data, err := validator.ValidateWithDefaults(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"firstName": "Ada"}`),
+		Format:     FormatJSON,
+		Name:       "config",
+	})
+	if err != nil {
+		t.Fatalf("ValidateWithDefaults failed: %v", err)
+	}
+	if result.Valid || data != nil {
+		t.Errorf("expected invalid result without data, got %v, %q", result.Valid, data)
+	}
+}
+
+// TestValidateAndPatch tests adding defaulted fields to inputs
+func TestValidateAndPatch(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	name:     string
+	replicas: *1 | int
+	id:       "app-" + name
+	resources: {
+		cpu:    *"100m" | string
+		memory: *"128Mi" | string
+	}
+}`, ValidationOptions{Export: ExportOptions{PreserveComments: true}})
+
+	tests := []struct {
+		name        string
+		data        string
+		format      DataFormat
+		wantValid   bool
+		wantPatched bool
+		want        string
+	}{
+		{
+			name:        "missing defaulted fields",
+			data:        "# service\nname: app # unique\nresources:\n  cpu: 500m\n",
+			format:      FormatYAML,
+			wantValid:   true,
+			wantPatched: true,
+			want:        "# service\nname: app # unique\nreplicas: 1\nresources:\n  cpu: 500m\n  memory: 128Mi\n",
+		},
+		{
+			name:        "missing struct",
+			data:        `{"name": "app", "replicas": 2}`,
+			format:      FormatJSON,
+			wantValid:   true,
+			wantPatched: true,
+			want:        "{\n  \"name\": \"app\",\n  \"replicas\": 2,\n  \"resources\": {\n    \"cpu\": \"100m\",\n    \"memory\": \"128Mi\"\n  }\n}\n",
+		},
+		{
+			name:      "nothing to patch",
+			data:      `{"name": "app", "replicas": 2, "resources": {"cpu": "1", "memory": "1Gi"}}`,
+			format:    FormatJSON,
+			wantValid: true,
+		},
+		{
+			name:   "type conflict",
+			data:   `{"name": "app", "replicas": "two"}`,
+			format: FormatJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, data, patched, err := validator.ValidateAndPatch(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("ValidateAndPatch failed: %v", err)
+			}
+			if result.Valid != tt.wantValid || patched != tt.wantPatched {
+				t.Fatalf("got Valid=%v patched=%v, want %v and %v: %v", result.Valid, patched, tt.wantValid, tt.wantPatched, result.Errors)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateAndPatchRevalidates tests that patched configs keep their
+// number kinds and still validate
+func TestValidateAndPatchRevalidates(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {ratio: float, big: int, replicas: *1 | int}`)
+
+	tests := []struct {
+		name   string
+		data   string
+		format DataFormat
+		want   string
+	}{
+		{
+			name:   "yaml",
+			data:   "ratio: 1.0\nbig: 12345678901234567890\n",
+			format: FormatYAML,
+			want:   "ratio: 1.0\nbig: 12345678901234567890\nreplicas: 1\n",
+		},
+		{
+			name:   "json",
+			data:   `{"ratio": 1.0, "big": 12345678901234567890}`,
+			format: FormatJSON,
+			want:   "{\n  \"ratio\": 1.0,\n  \"big\": 12345678901234567890,\n  \"replicas\": 1\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, data, patched, err := validator.ValidateAndPatch(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil || !patched {
+				t.Fatalf("ValidateAndPatch = patched %v, %v", patched, err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", data, tt.want)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       data,
+				Format:     tt.format,
+				Name:       "patched",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if !result.Valid {
+				t.Errorf("patched config fails the schema: %v", result.Errors)
+			}
+		})
+	}
+}
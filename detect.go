@@ -0,0 +1,51 @@
+package cuebridge
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// resolveFormat returns the concrete format of an input,
+// detecting it when the input uses FormatAuto
+func resolveFormat(input ValidationInput, data []byte) DataFormat {
+	if input.Format != FormatAuto {
+		return input.Format
+	}
+
+	name := input.Name
+	if input.SourceType == SourceFile {
+		name = input.FilePath
+	}
+	if format, ok := formatFromExtension(name); ok {
+		return format
+	}
+
+	return formatFromContent(data)
+}
+
+// formatFromExtension detects the format from a file name extension
+func formatFromExtension(name string) (DataFormat, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".toml":
+		return FormatTOML, true
+	case ".jsonc":
+		return FormatJSONC, true
+	default:
+		return 0, false
+	}
+}
+
+// formatFromContent detects the format from the data itself:
+// a document starting with '{' or '[' is JSON, anything else is YAML
+func formatFromContent(data []byte) DataFormat {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJSON
+	}
+	return FormatYAML
+}
@@ -0,0 +1,74 @@
+package cuebridge
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// checkDiscriminator verifies the discriminator options: both or neither
+// must be set, and each mapped definition must exist in the schema
+func checkDiscriminator(schema cue.Value, opts ValidationOptions) error {
+	if opts.DiscriminatorField == "" && len(opts.DiscriminatorMap) == 0 {
+		return nil
+	}
+	if opts.DiscriminatorField == "" || len(opts.DiscriminatorMap) == 0 {
+		return fmt.Errorf("DiscriminatorField and DiscriminatorMap must be set together")
+	}
+	if path := cue.ParsePath(opts.DiscriminatorField); path.Err() != nil {
+		return fmt.Errorf("invalid discriminator field %s: %w", opts.DiscriminatorField, path.Err())
+	}
+
+	for _, value := range slices.Sorted(maps.Keys(opts.DiscriminatorMap)) {
+		definitionName := opts.DiscriminatorMap[value]
+		if !schema.LookupPath(cue.ParsePath(definitionName)).Exists() {
+			return fmt.Errorf("discriminator %q: schema does not define %s", value, definitionName)
+		}
+	}
+	return nil
+}
+
+// discriminatedDefinition returns the definition mapped to the value of the
+// discriminator field in data, or an error describing why there is none.
+// prefix is the path of data within the input (e.g., the InputPath).
+func (v *Validator) discriminatedDefinition(data cue.Value, prefix string) (string, *ValidationError) {
+	fieldName := v.options.DiscriminatorField
+	errorPath := fieldName
+	if prefix != "" {
+		errorPath = prefix + "." + fieldName
+	}
+	pointer := jsonPointer(strings.Split(errorPath, "."))
+
+	field := data.LookupPath(cue.ParsePath(fieldName))
+	if !field.Exists() {
+		return "", &ValidationError{
+			Path:    errorPath,
+			Pointer: pointer,
+			Message: fmt.Sprintf("missing discriminator field %s", fieldName),
+		}
+	}
+
+	fail := func(message string) (string, *ValidationError) {
+		return "", &ValidationError{
+			Line:    field.Pos().Line(),
+			Column:  field.Pos().Column(),
+			Path:    errorPath,
+			Pointer: pointer,
+			Message: message,
+		}
+	}
+
+	value, err := field.String()
+	if err != nil {
+		return fail(fmt.Sprintf("discriminator field %s must be a string, got %s", fieldName, field.IncompleteKind()))
+	}
+	definitionName, ok := v.options.DiscriminatorMap[value]
+	if !ok {
+		known := slices.Sorted(maps.Keys(v.options.DiscriminatorMap))
+		return fail(fmt.Sprintf("unknown %s %q (expected one of: %s)", fieldName, value, strings.Join(known, ", ")))
+	}
+	return definitionName, nil
+}
@@ -0,0 +1,172 @@
+package cuebridge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestDiscriminator tests selecting the definition by a field of the input
+func TestDiscriminator(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {...}
+#Service: {kind: "Service", port: int}
+#Deployment: {kind: "Deployment", replicas: int}`, ValidationOptions{
+		DiscriminatorField: "kind",
+		DiscriminatorMap:   map[string]string{"Service": "#Service", "Deployment": "#Deployment"},
+	})
+
+	tests := []struct {
+		name           string
+		data           string
+		definition     string
+		inputPath      string
+		wantValid      bool
+		wantDefinition string
+		wantPath       string
+		wantMessage    string
+	}{
+		{name: "service", data: `{"kind": "Service", "port": 80}`, wantValid: true, wantDefinition: "#Service"},
+		{name: "deployment", data: `{"kind": "Deployment", "replicas": 2}`, wantValid: true, wantDefinition: "#Deployment"},
+		{name: "invalid for selected definition", data: `{"kind": "Service", "replicas": 2}`, wantDefinition: "#Service"},
+		{name: "missing field", data: `{"port": 80}`, wantDefinition: "#Config", wantPath: "kind", wantMessage: "missing discriminator field kind"},
+		{name: "not a string", data: `{"kind": 1}`, wantDefinition: "#Config", wantPath: "kind", wantMessage: "discriminator field kind must be a string, got int"},
+		{name: "unmapped value", data: `{"kind": "Ingress"}`, wantDefinition: "#Config", wantPath: "kind", wantMessage: `unknown kind "Ingress" (expected one of: Deployment, Service)`},
+		{name: "explicit definition wins", data: `{"kind": "Ingress"}`, definition: "#Config", wantValid: true, wantDefinition: "#Config"},
+		{name: "within input path", data: `{"spec": {"kind": "Deployment", "replicas": 1}}`, inputPath: "spec", wantValid: true, wantDefinition: "#Deployment"},
+		{name: "missing within input path", data: `{"spec": {}}`, inputPath: "spec", wantDefinition: "#Config", wantPath: "spec.kind", wantMessage: "missing discriminator field kind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+				Definition: tt.definition,
+				InputPath:  tt.inputPath,
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Definition != tt.wantDefinition {
+				t.Errorf("Definition = %q, want %q", result.Definition, tt.wantDefinition)
+			}
+			if tt.wantMessage == "" {
+				return
+			}
+			if len(result.Errors) != 1 || result.Errors[0].Path != tt.wantPath || result.Errors[0].Message != tt.wantMessage {
+				t.Errorf("errors = %v, want %q at %q", result.Errors, tt.wantMessage, tt.wantPath)
+			}
+		})
+	}
+}
+
+// TestDiscriminatorMethods tests that the methods besides Validate select
+// the definition by the discriminator field
+func TestDiscriminatorMethods(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {...}
+#Service: {kind: "Service", port: int, protocol: *"TCP" | "UDP"}
+#Deployment: {kind: "Deployment", replicas: int}`, ValidationOptions{
+		DiscriminatorField: "kind",
+		DiscriminatorMap:   map[string]string{"Service": "#Service", "Deployment": "#Deployment"},
+	})
+
+	input := func(data string) ValidationInput {
+		return ValidationInput{SourceType: SourceBytes, Data: []byte(data), Format: FormatYAML, Name: "config.yaml"}
+	}
+
+	t.Run("ValidateStream", func(t *testing.T) {
+		stream, err := validator.ValidateStream(input("kind: Service\nport: notanint\n---\nkind: Deployment\nreplicas: 2\n---\nkind: Ingress\n"))
+		if err != nil {
+			t.Fatalf("ValidateStream failed: %v", err)
+		}
+		if len(stream.Results) != 3 {
+			t.Fatalf("got %d results, want 3", len(stream.Results))
+		}
+		for i, want := range []struct {
+			valid      bool
+			definition string
+		}{{false, "#Service"}, {true, "#Deployment"}, {false, "#Config"}} {
+			result := stream.Results[i]
+			if result.Valid != want.valid || result.Definition != want.definition {
+				t.Errorf("document %d: Valid = %v, Definition = %q, want %v, %q (errors: %v)", i, result.Valid, result.Definition, want.valid, want.definition, result.Errors)
+			}
+			if wantName := fmt.Sprintf("config.yaml[%d]", i); result.Name != wantName {
+				t.Errorf("document %d: Name = %q, want %q", i, result.Name, wantName)
+			}
+		}
+	})
+
+	t.Run("ValidateWithDefaultReport", func(t *testing.T) {
+		result, defaulted, err := validator.ValidateWithDefaultReport(input("kind: Service\nport: 80\n"))
+		if err != nil {
+			t.Fatalf("ValidateWithDefaultReport failed: %v", err)
+		}
+		if !result.Valid || result.Definition != "#Service" || !slices.Equal(defaulted, []string{"protocol"}) {
+			t.Errorf("got %v, %q, %v, want valid #Service with protocol defaulted (errors: %v)", result.Valid, result.Definition, defaulted, result.Errors)
+		}
+	})
+
+	t.Run("ValidateLayered", func(t *testing.T) {
+		result, _, err := validator.ValidateLayered(input("kind: Service\nport: 80\n"), input("port: http\n"))
+		if err != nil {
+			t.Fatalf("ValidateLayered failed: %v", err)
+		}
+		if result.Valid || result.Definition != "#Service" {
+			t.Errorf("got %v, %q, want invalid #Service (errors: %v)", result.Valid, result.Definition, result.Errors)
+		}
+	})
+
+	t.Run("ValidateForeignValue", func(t *testing.T) {
+		value := validator.ctx.CompileString(`{kind: "Deployment", replicas: "two"}`)
+		result, err := validator.ValidateForeignValue(value, "value")
+		if err != nil {
+			t.Fatalf("ValidateForeignValue failed: %v", err)
+		}
+		if result.Valid || result.Definition != "#Deployment" {
+			t.Errorf("got %v, %q, want invalid #Deployment (errors: %v)", result.Valid, result.Definition, result.Errors)
+		}
+	})
+}
+
+// TestDiscriminatorOptions tests checking the discriminator options
+func TestDiscriminatorOptions(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	if err := os.WriteFile(schemaPath, []byte(`#Config: {...}
+#Service: {kind: "Service"}`), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    ValidationOptions
+		wantErr string
+	}{
+		{name: "valid", opts: ValidationOptions{DiscriminatorField: "kind", DiscriminatorMap: map[string]string{"Service": "#Service"}}},
+		{name: "field without map", opts: ValidationOptions{DiscriminatorField: "kind"}, wantErr: "must be set together"},
+		{name: "map without field", opts: ValidationOptions{DiscriminatorMap: map[string]string{"Service": "#Service"}}, wantErr: "must be set together"},
+		{name: "undefined definition", opts: ValidationOptions{DiscriminatorField: "kind", DiscriminatorMap: map[string]string{"Ingress": "#Ingress"}}, wantErr: `discriminator "Ingress": schema does not define #Ingress`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidatorWithOptions(schemaPath, "#Config", tt.opts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,13 +1,74 @@
 package cuebridge
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
+	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/token"
 )
 
+// ValidationErrors is a list of validation errors that implements the error
+// interface, so validation failures can be returned through ordinary Go
+// error handling and recovered with errors.As.
+type ValidationErrors []ValidationError
+
+// Error joins the individual error descriptions with "; "
+func (errs ValidationErrors) Error() string {
+	descriptions := make([]string, len(errs))
+	for i, e := range errs {
+		descriptions[i] = e.Error()
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// Unwrap returns the individual errors, for use with errors.Is and errors.As
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, e := range errs {
+		unwrapped[i] = e
+	}
+	return unwrapped
+}
+
+// Error describes the error with its location,
+// e.g. `line 5, field "replicas": invalid value 0`
+func (e ValidationError) Error() string {
+	if e.Condition != "" {
+		e.Message = fmt.Sprintf("%s (applies when %s)", e.Message, e.Condition)
+	}
+	switch {
+	case e.Line > 0 && e.Path != "":
+		return fmt.Sprintf("line %d, field \"%s\": %s", e.Line, e.Path, e.Message)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	case e.Path != "":
+		return fmt.Sprintf("field \"%s\": %s", e.Path, e.Message)
+	default:
+		return e.Message
+	}
+}
+
+// Err returns the result's errors (excluding warnings) as ValidationErrors,
+// or nil if the result is valid.
+func (r ValidationResult) Err() error {
+	if r.Valid {
+		return nil
+	}
+
+	var errs ValidationErrors
+	for _, e := range r.Errors {
+		if e.Severity == SeverityError {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
 // extractValidationErrors extracts structured error information from CUE errors
-func extractValidationErrors(err error) []ValidationError {
+func extractValidationErrors(err error, source *sourceContext) []ValidationError {
 	cueErrors := errors.Errors(err)
 	if len(cueErrors) == 0 {
 		return []ValidationError{
@@ -17,7 +78,7 @@ func extractValidationErrors(err error) []ValidationError {
 
 	var validationErrors []ValidationError
 	for _, e := range cueErrors {
-		ve := extractSingleError(e)
+		ve := extractSingleError(e, source)
 		validationErrors = append(validationErrors, ve)
 	}
 
@@ -25,18 +86,86 @@ func extractValidationErrors(err error) []ValidationError {
 }
 
 // extractSingleError extracts information from a single CUE error
-func extractSingleError(e errors.Error) ValidationError {
+func extractSingleError(e errors.Error, source *sourceContext) ValidationError {
+	path := extractFieldPath(e)
 	return ValidationError{
-		Line:    extractLineNumber(e),
-		Column:  extractColumnNumber(e),
-		Path:    extractFieldPath(e),
-		Message: e.Error(),
+		Line:       extractLineNumber(e, source),
+		Column:     extractColumnNumber(e, source),
+		Path:       path,
+		Pointer:    jsonPointer(e.Path()),
+		Message:    rewriteMessage(e.Error(), e.Path(), source),
+		RawMessage: e.Error(),
+		SourceLine: extractSourceLine(e, source),
+		GotType:    extractGotType(e, source),
 	}
 }
 
-// extractLineNumber extracts the line number from error positions
-func extractLineNumber(e errors.Error) int {
+// extractGotType returns the kind of the input value at the error path
+// (e.g., "string"), or "" if the input has no value there
+func extractGotType(e errors.Error, source *sourceContext) string {
+	if source == nil || !source.value.Exists() {
+		return ""
+	}
+
+	value := source.value.LookupPath(inputPath(e.Path()))
+	if !value.Exists() {
+		return ""
+	}
+	return value.IncompleteKind().String()
+}
+
+// inputPath converts CUE error path elements into a path into the input value
+func inputPath(path []string) cue.Path {
+	var selectors []cue.Selector
+	for _, p := range path {
+		if !isValidPathElement(p) {
+			continue
+		}
+		if index, err := strconv.Atoi(p); err == nil {
+			selectors = append(selectors, cue.Index(index))
+			continue
+		}
+		sel := cue.ParsePath(p)
+		if sel.Err() != nil {
+			selectors = append(selectors, cue.Str(strings.Trim(p, `"`)))
+			continue
+		}
+		selectors = append(selectors, sel.Selectors()...)
+	}
+	return cue.MakePath(selectors...)
+}
+
+// errorPositions returns the positions attached to an error. The error's
+// own position comes first, followed by the positions it refers to, those
+// inside the input ahead of the schema's: CUE sorts them by file name, and
+// the absolute schema paths from loading would otherwise win over any
+// input name. When input positions are preferred, positions inside the
+// input come first overall, so the input side wins over the schema side.
+func errorPositions(e errors.Error, source *sourceContext) []token.Pos {
 	positions := errors.Positions(e)
+	if source == nil {
+		return positions
+	}
+
+	fixed := 0
+	if pos := e.Position(); !source.preferInputPositions && pos.IsValid() && len(positions) > 0 && positions[0] == pos {
+		fixed = 1
+	}
+
+	var inputPositions, otherPositions []token.Pos
+	for _, pos := range positions[fixed:] {
+		if pos.Filename() == source.filename {
+			inputPositions = append(inputPositions, pos)
+		} else {
+			otherPositions = append(otherPositions, pos)
+		}
+	}
+	return append(append(positions[:fixed:fixed], inputPositions...), otherPositions...)
+}
+
+// extractLineNumber extracts the line number from error positions
+func extractLineNumber(e errors.Error, source *sourceContext) int {
+	positions := errorPositions(e, source)
 	for _, pos := range positions {
 		if line := pos.Line(); line > 0 {
 			return line
@@ -46,8 +175,8 @@ func extractLineNumber(e errors.Error) int {
 }
 
 // extractColumnNumber extracts the column number from error positions
-func extractColumnNumber(e errors.Error) int {
-	positions := errors.Positions(e)
+func extractColumnNumber(e errors.Error, source *sourceContext) int {
+	positions := errorPositions(e, source)
 	for _, pos := range positions {
 		if col := pos.Column(); col > 0 {
 			return col
@@ -56,6 +185,46 @@ func extractColumnNumber(e errors.Error) int {
 	return 0
 }
 
+// extractSourceLine returns the text of the first input line the error refers to,
+// if source line capture is enabled
+func extractSourceLine(e errors.Error, source *sourceContext) string {
+	if source == nil || !source.captureLines {
+		return ""
+	}
+
+	for _, pos := range errors.Positions(e) {
+		if pos.Line() > 0 && pos.Filename() == source.filename {
+			return sourceLine(source.data, pos.Line())
+		}
+	}
+	return ""
+}
+
+// withSourceLines fills in the source line of errors that were not extracted
+// from CUE errors (e.g., parse failures), if source line capture is enabled.
+// The input is retained in source for every source type, so this works for
+// readers and byte slices as well as files.
+func withSourceLines(result ValidationResult, source *sourceContext) ValidationResult {
+	if source == nil || !source.captureLines {
+		return result
+	}
+	for i, e := range result.Errors {
+		if e.Line > 0 && e.SourceLine == "" {
+			result.Errors[i].SourceLine = sourceLine(source.data, e.Line)
+		}
+	}
+	return result
+}
+
+// sourceLine returns the text of a 1-based line in data
+func sourceLine(data []byte, line int) string {
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[line-1], "\r")
+}
+
 // extractFieldPath extracts and formats the field path from error
 func extractFieldPath(e errors.Error) string {
 	path := e.Path()
@@ -80,9 +249,54 @@ func formatPath(path []string) string {
 	return strings.Join(parts, ".")
 }
 
-// isValidPathElement checks if a path element should be included
+// jsonPointer converts CUE path to an RFC 6901 JSON Pointer
+// (e.g., "/spec/containers/0/image")
+func jsonPointer(path []string) string {
+	var pointer strings.Builder
+	for _, p := range path {
+		if !isValidPathElement(p) {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(p); err == nil {
+			p = unquoted
+		}
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+		pointer.WriteString("/" + p)
+	}
+	return pointer.String()
+}
+
+// pointerElements splits an RFC 6901 JSON Pointer into its unescaped
+// elements (e.g., "/spec/containers/0" into spec, containers, and 0)
+func pointerElements(pointer string) []string {
+	elements := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, element := range elements {
+		element = strings.ReplaceAll(element, "~1", "/")
+		elements[i] = strings.ReplaceAll(element, "~0", "~")
+	}
+	return elements
+}
+
+// withPathFormatter reformats the paths of result's errors with the
+// validator's PathFormatter, if set. Errors without a pointer keep their
+// path, since their elements are unknown.
+func (v *Validator) withPathFormatter(result ValidationResult) ValidationResult {
+	if v.options.PathFormatter == nil {
+		return result
+	}
+	for i, e := range result.Errors {
+		if e.Pointer != "" {
+			result.Errors[i].Path = v.options.PathFormatter(pointerElements(e.Pointer))
+		}
+	}
+	return result
+}
+
+// isValidPathElement checks if a path element should be included.
+// Definition names (e.g., "#Config") are schema-side and never part of the input path.
 func isValidPathElement(p string) bool {
 	return p != "" &&
 		!strings.HasPrefix(p, "[") &&
-		p != "#Config"
+		!strings.HasPrefix(p, "#")
 }
@@ -0,0 +1,479 @@
+package cuebridge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGotType tests reporting the kind of the offending input value
+func TestGotType(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {port: int, items: [...{enabled: bool}]}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"port": "8080", "items": [{"enabled": true}, {"enabled": 1}]}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string]string{
+		"port":            "string",
+		"items.1.enabled": "int",
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(result.Errors), len(want), result.Errors)
+	}
+	for _, e := range result.Errors {
+		if e.GotType != want[e.Path] {
+			t.Errorf("%s: GotType = %q, want %q", e.Path, e.GotType, want[e.Path])
+		}
+	}
+}
+
+// TestValidationErrors tests returning a result as a Go error
+func TestValidationErrors(t *testing.T) {
+	result := ValidationResult{
+		Name:  "config.yaml",
+		Valid: false,
+		Errors: []ValidationError{
+			{Line: 5, Path: "replicas", Message: "invalid value 0"},
+			{Path: "legacy", Message: "field is deprecated", Severity: SeverityWarning},
+		},
+	}
+
+	err := fmt.Errorf("loading config: %w", result.Err())
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("errors.As failed for %v", err)
+	}
+	if len(errs) != 1 || errs[0].Path != "replicas" {
+		t.Errorf("errs = %v, want only the replicas error", errs)
+	}
+	if got := errs.Error(); got != `line 5, field "replicas": invalid value 0` {
+		t.Errorf("Error() = %q", got)
+	}
+
+	var single ValidationError
+	if !errors.As(err, &single) || single.Line != 5 {
+		t.Errorf("errors.As for a single ValidationError failed: %v", single)
+	}
+
+	if err := (ValidationResult{Valid: true}).Err(); err != nil {
+		t.Errorf("Err() for valid result = %v, want nil", err)
+	}
+}
+
+// TestPreferInputPositions tests that the input-side position wins
+func TestPreferInputPositions(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	name:     string
+	replicas: int & >=1
+}`, ValidationOptions{PreferInputPositions: true})
+	input := ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\n\n\nreplicas: 0\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	}
+
+	result, err := validator.Validate(input)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(result.Errors), result.Errors)
+	}
+	if e := result.Errors[0]; e.Line != 4 || e.Column != 11 {
+		t.Errorf("got line %d, column %d, want line 4, column 11", e.Line, e.Column)
+	}
+}
+
+// TestJSONPointer tests reporting error locations as JSON Pointers
+func TestJSONPointer(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	spec: containers: [...{image: string}]
+	labels: [string]: string
+}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"spec": {"containers": [{"image": "nginx"}, {"image": 1}]}, "labels": {"app/name~x": 2}}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string]string{
+		"spec.containers.1.image": "/spec/containers/1/image",
+		"labels.app/name~x":       "/labels/app~1name~0x",
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(result.Errors), len(want), result.Errors)
+	}
+	for _, e := range result.Errors {
+		if e.Pointer != want[e.Path] {
+			t.Errorf("%s: Pointer = %q, want %q", e.Path, e.Pointer, want[e.Path])
+		}
+	}
+}
+
+// TestPathFormatter tests formatting error paths with a custom formatter
+func TestPathFormatter(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	spec: containers: [...{image: string}]
+	labels: [string]: string
+}`, ValidationOptions{
+		PathFormatter: func(elements []string) string {
+			var path strings.Builder
+			for _, element := range elements {
+				path.WriteString(fmt.Sprintf("[%q]", element))
+			}
+			return path.String()
+		},
+	})
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"spec": {"containers": [{"image": "nginx"}, {"image": 1}]}, "labels": {"app/name~x": 2}}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string]string{
+		`["spec"]["containers"]["1"]["image"]`: "/spec/containers/1/image",
+		`["labels"]["app/name~x"]`:             "/labels/app~1name~0x",
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(result.Errors), len(want), result.Errors)
+	}
+	for _, e := range result.Errors {
+		pointer, ok := want[e.Path]
+		if !ok {
+			t.Errorf("unexpected path %q", e.Path)
+			continue
+		}
+		if e.Pointer != pointer {
+			t.Errorf("%s: Pointer = %q, want %q", e.Path, e.Pointer, pointer)
+		}
+	}
+}
+
+// TestSourceLinesForAllSources tests that source lines are captured
+// for file, reader, and byte inputs, including parse errors
+func TestSourceLinesForAllSources(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string, replicas: int & >=1}`, ValidationOptions{CaptureSourceLines: true})
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "validation error", data: "name: app\nreplicas: 0\n", want: "replicas: 0"},
+		{name: "parse error", data: "name: app\nspec:\n  kind: web\n\treplicas: 1\n", want: "\treplicas: 1"},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		inputs := map[string]ValidationInput{
+			"file":   {SourceType: SourceFile, FilePath: path},
+			"reader": {SourceType: SourceReader, Reader: strings.NewReader(tt.data)},
+			"bytes":  {SourceType: SourceBytes, Data: []byte(tt.data)},
+		}
+		for source, input := range inputs {
+			input.Format = FormatYAML
+			input.Name = "config.yaml"
+			result, err := validator.Validate(input)
+			if err != nil {
+				t.Fatalf("%s/%s: Validate failed: %v", tt.name, source, err)
+			}
+			if result.Valid || len(result.Errors) == 0 {
+				t.Fatalf("%s/%s: expected errors, got %+v", tt.name, source, result)
+			}
+			if got := result.Errors[0].SourceLine; got != tt.want {
+				t.Errorf("%s/%s: SourceLine = %q, want %q", tt.name, source, got, tt.want)
+			}
+		}
+	}
+}
+
+// TestListLengthMessages tests plain messages for list length violations
+func TestListLengthMessages(t *testing.T) {
+	validator := newTestValidator(t, `import "list"
+
+#Config: {
+	tags:  [...string] & list.MinItems(1)
+	ports: [...int] & list.MaxItems(2)
+	rules: [...{hosts: [...string] & list.MinItems(2)}]
+}`)
+
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		want     string
+	}{
+		{name: "min items", data: `{"tags": [], "ports": [], "rules": []}`, wantPath: "tags", want: "list at tags must have at least 1 item (got 0)"},
+		{name: "max items", data: `{"tags": ["a"], "ports": [1, 2, 3], "rules": []}`, wantPath: "ports", want: "list at ports must have at most 2 items (got 3)"},
+		{name: "nested list", data: `{"tags": ["a"], "ports": [], "rules": [{"hosts": ["a"]}]}`, wantPath: "rules.0.hosts", want: "list at rules.0.hosts must have at least 2 items (got 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected 1 error, got %v", result.Errors)
+			}
+			if e := result.Errors[0]; e.Path != tt.wantPath || e.Message != tt.want {
+				t.Errorf("got %q at %q, want %q at %q", e.Message, e.Path, tt.want, tt.wantPath)
+			}
+		})
+	}
+}
+
+// TestZeroVersusMissing tests distinct messages for zero and missing values
+func TestZeroVersusMissing(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	replicas: int & >0
+	spec: {port: int & >=1 & <=65535}
+	name: string
+}`)
+
+	tests := []struct {
+		name string
+		data string
+		want map[string]string
+	}{
+		{
+			name: "zero values",
+			data: `{"replicas": 0, "spec": {"port": 0}, "name": "app"}`,
+			want: map[string]string{
+				"replicas":  "value 0 does not satisfy >0",
+				"spec.port": "value 0 does not satisfy >=1",
+			},
+		},
+		{
+			name: "missing fields",
+			data: `{"spec": {}}`,
+			want: map[string]string{
+				"replicas":  "missing required field",
+				"spec.port": "missing required field",
+				"name":      "missing required field",
+			},
+		},
+		{
+			name: "out of range",
+			data: `{"replicas": 1, "spec": {"port": 70000}, "name": "app"}`,
+			want: map[string]string{
+				"spec.port": "value 70000 does not satisfy <=65535",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			got := map[string]string{}
+			for _, e := range result.Errors {
+				got[e.Path] = e.Message
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRegexpMessages tests messages for strings failing a regexp constraint
+func TestRegexpMessages(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:    =~"^[a-z][a-z0-9-]*$"
+	version: =~#"^\d+\.\d+$"#
+	branch:  string & !~"^tmp/"
+}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "My App", "version": "1.x", "branch": "tmp/test"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string]string{
+		"name":    `value "My App" does not match pattern ^[a-z][a-z0-9-]*$`,
+		"version": `value "1.x" does not match pattern ^\d+\.\d+$`,
+		"branch":  `value "tmp/test" must not match pattern ^tmp/`,
+	}
+	got := map[string]string{}
+	for _, e := range result.Errors {
+		got[e.Path] = e.Message
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+}
+
+// TestRawMessage tests keeping the CUE message alongside a rewritten one
+func TestRawMessage(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: =~"^[a-z]+$", port: int}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "App", "port": "80"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string][2]string{
+		"name": {`value "App" does not match pattern ^[a-z]+$`, `#Config.name: invalid value "App" (out of bound =~"^[a-z]+$")`},
+		"port": {"", ""},
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(result.Errors), len(want), result.Errors)
+	}
+	for _, e := range result.Errors {
+		if e.RawMessage == "" {
+			t.Errorf("%s: RawMessage is empty", e.Path)
+		}
+		messages := want[e.Path]
+		if messages[0] == "" {
+			// Messages that are not rewritten are the same in both fields
+			if e.Message != e.RawMessage {
+				t.Errorf("%s: Message = %q, RawMessage = %q, want them equal", e.Path, e.Message, e.RawMessage)
+			}
+			continue
+		}
+		if e.Message != messages[0] || e.RawMessage != messages[1] {
+			t.Errorf("%s: Message = %q, RawMessage = %q, want %q, %q", e.Path, e.Message, e.RawMessage, messages[0], messages[1])
+		}
+	}
+}
+
+// TestFieldSuggestions tests suggesting known names for unknown fields
+func TestFieldSuggestions(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	region: string
+	name?:  string
+	spec: {replicas: int, image: string}
+	ports: [...{port: int}]
+}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"reigon": "eu", "reigonx": "eu", "Name": "app", "spec": {"replicas": 1, "imgae": "nginx"}, "ports": [{"prot": 80}], "zone": "a"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	want := map[string]string{
+		"reigon":       `unknown field "reigon"; did you mean "region"?`,
+		"reigonx":      `unknown field "reigonx"; did you mean "region"?`,
+		"Name":         `unknown field "Name"; did you mean "name"?`,
+		"spec.imgae":   `unknown field "imgae"; did you mean "image"?`,
+		"ports.0.prot": `unknown field "prot"; did you mean "port"?`,
+	}
+	got := map[string]string{}
+	for _, e := range result.Errors {
+		got[e.Path] = e.Message
+	}
+	// No field is close to "zone"
+	if !strings.HasSuffix(got["zone"], "field not allowed") {
+		t.Errorf("zone: got %q, want the unchanged message", got["zone"])
+	}
+	delete(got, "zone")
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+}
+
+// TestEditDistance tests the distance used for field suggestions
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"region", "region", 0},
+		{"reigon", "region", 1},
+		{"imgae", "image", 1},
+		{"replica", "replicas", 1},
+		{"port", "host", 2},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestRelativeSchemaPathPositions tests that errors point into the input
+// when the schema is loaded from a relative path, whose files the loader
+// names by absolute path
+func TestRelativeSchemaPathPositions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	schema := "#Config: {\n\tname: string\n\n\n\ttags: [...string]\n}\n"
+	if err := os.WriteFile("schema.cue", []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+	validator, err := NewValidator("schema.cue", "#Config")
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\n\n\ntags: [1]\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(result.Errors), result.Errors)
+	}
+	if e := result.Errors[0]; e.Line != 4 || e.Column != 8 {
+		t.Errorf("got line %d, column %d, want line 4, column 8", e.Line, e.Column)
+	}
+}
@@ -0,0 +1,298 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/encoding/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultExportIndent is the indentation width used when ExportOptions.Indent is 0
+const defaultExportIndent = 2
+
+// exportValue encodes a concrete CUE value in the given data format.
+// source is the YAML input the value came from, whose comments are kept
+// if ExportOptions.PreserveComments is set (nil if there is none).
+func exportValue(value cue.Value, format DataFormat, opts ExportOptions, source []byte) ([]byte, error) {
+	switch format {
+	case FormatJSON, FormatJSONC:
+		return exportJSON(value, opts)
+	case FormatYAML:
+		return exportYAML(value, opts, source)
+	case FormatTOML:
+		return exportTOML(value)
+	default:
+		return nil, fmt.Errorf("unsupported format: %d", format)
+	}
+}
+
+// exportIndent returns the configured indentation width
+func exportIndent(opts ExportOptions) int {
+	if opts.Indent > 0 {
+		return opts.Indent
+	}
+	return defaultExportIndent
+}
+
+// exportJSON encodes a CUE value as indented JSON
+func exportJSON(value cue.Value, opts ExportOptions) ([]byte, error) {
+	var data []byte
+	var err error
+	if opts.SortKeys {
+		data, err = sortedJSON(value)
+	} else {
+		data, err = value.MarshalJSON()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+
+	var output bytes.Buffer
+	indent := strings.Repeat(" ", exportIndent(opts))
+	if err := json.Indent(&output, data, "", indent); err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	output.WriteByte('\n')
+
+	return output.Bytes(), nil
+}
+
+// sortedJSON encodes a CUE value as compact JSON with the keys of every
+// object sorted. Scalars are encoded by CUE, so numbers keep their kind
+// (e.g., 1.0 stays a float) and integers of any size stay numbers.
+func sortedJSON(value cue.Value) ([]byte, error) {
+	switch value.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := value.Fields()
+		if err != nil {
+			return nil, err
+		}
+		fields := map[string][]byte{}
+		for iter.Next() {
+			data, err := sortedJSON(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			fields[iter.Selector().Unquoted()] = data
+		}
+
+		var output bytes.Buffer
+		output.WriteByte('{')
+		for i, key := range slices.Sorted(maps.Keys(fields)) {
+			if i > 0 {
+				output.WriteByte(',')
+			}
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			output.Write(encodedKey)
+			output.WriteByte(':')
+			output.Write(fields[key])
+		}
+		output.WriteByte('}')
+		return output.Bytes(), nil
+
+	case cue.ListKind:
+		iter, err := value.List()
+		if err != nil {
+			return nil, err
+		}
+		var output bytes.Buffer
+		output.WriteByte('[')
+		for i := 0; iter.Next(); i++ {
+			if i > 0 {
+				output.WriteByte(',')
+			}
+			data, err := sortedJSON(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			output.Write(data)
+		}
+		output.WriteByte(']')
+		return output.Bytes(), nil
+
+	default:
+		return value.MarshalJSON()
+	}
+}
+
+// exportTOML encodes a CUE value as TOML. The encoder sorts keys and uses
+// its own layout, so the export options do not apply.
+func exportTOML(value cue.Value) ([]byte, error) {
+	var output bytes.Buffer
+	if err := toml.NewEncoder(&output).Encode(value); err != nil {
+		return nil, fmt.Errorf("encoding TOML: %w", err)
+	}
+	return output.Bytes(), nil
+}
+
+// exportYAML encodes a CUE value as YAML, with the comments of source
+// if they are to be preserved
+func exportYAML(value cue.Value, opts ExportOptions, source []byte) ([]byte, error) {
+	node, err := yamlNode(value, opts)
+	if err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if opts.PreserveComments && source != nil {
+		var document yaml.Node
+		// The source has been parsed already; if yaml.v3 disagrees, keep no comments
+		if yaml.Unmarshal(source, &document) == nil && len(document.Content) > 0 {
+			copyYAMLComments(node, document.Content[0])
+			document.Content = []*yaml.Node{node}
+			node = &document
+		}
+	}
+
+	var output bytes.Buffer
+	encoder := yaml.NewEncoder(&output)
+	encoder.SetIndent(exportIndent(opts))
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	return output.Bytes(), nil
+}
+
+// yamlNode converts a concrete CUE value into a YAML node tree,
+// keeping field order unless keys are sorted
+func yamlNode(value cue.Value, opts ExportOptions) (*yaml.Node, error) {
+	style := yaml.Style(0)
+	if opts.YAMLFlowStyle {
+		style = yaml.FlowStyle
+	}
+
+	switch value.IncompleteKind() {
+	case cue.StructKind:
+		node := &yaml.Node{Kind: yaml.MappingNode, Style: style}
+		iter, err := value.Fields()
+		if err != nil {
+			return nil, err
+		}
+
+		type field struct {
+			key   string
+			value *yaml.Node
+		}
+		var fields []field
+		for iter.Next() {
+			child, err := yamlNode(iter.Value(), opts)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{key: iter.Selector().Unquoted(), value: child})
+		}
+		if opts.SortKeys {
+			slices.SortStableFunc(fields, func(a, b field) int {
+				return strings.Compare(a.key, b.key)
+			})
+		}
+
+		for _, f := range fields {
+			key := &yaml.Node{}
+			if err := key.Encode(f.key); err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, key, f.value)
+		}
+		return node, nil
+
+	case cue.ListKind:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: style}
+		iter, err := value.List()
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			child, err := yamlNode(iter.Value(), opts)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+
+	default:
+		return yamlScalar(value)
+	}
+}
+
+// yamlScalar converts a concrete CUE scalar into a YAML node. Numbers are
+// written as CUE formats them and tagged with their kind, so a float such
+// as 1.0 is not read back as an int and an int beyond 64 bits is not
+// turned into a string.
+func yamlScalar(value cue.Value) (*yaml.Node, error) {
+	value, _ = value.Default()
+	var tag string
+	switch value.Kind() {
+	case cue.IntKind:
+		tag = "!!int"
+	case cue.FloatKind:
+		tag = "!!float"
+	default:
+		var scalar any
+		if err := value.Decode(&scalar); err != nil {
+			return nil, err
+		}
+		node := &yaml.Node{}
+		if err := node.Encode(scalar); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	literal, err := value.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(literal)}, nil
+}
+
+// copyYAMLComments copies the comments of the source node tree to the
+// matching nodes of the exported tree. Mapping entries are matched by key
+// and sequence elements by index. Comments on a scalar are copied only if
+// its value is unchanged, so they do not end up describing a new value.
+func copyYAMLComments(dst *yaml.Node, src *yaml.Node) {
+	switch src.Kind {
+	case yaml.AliasNode:
+		if src.Alias != nil {
+			copyYAMLComments(dst, src.Alias)
+		}
+		return
+	}
+
+	if dst.Kind != src.Kind || (dst.Kind == yaml.ScalarNode && dst.Value != src.Value) {
+		return
+	}
+	dst.HeadComment = src.HeadComment
+	dst.LineComment = src.LineComment
+	dst.FootComment = src.FootComment
+
+	switch dst.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			for j := 0; j+1 < len(src.Content); j += 2 {
+				if src.Content[j].Value != dst.Content[i].Value {
+					continue
+				}
+				copyYAMLComments(dst.Content[i], src.Content[j])
+				copyYAMLComments(dst.Content[i+1], src.Content[j+1])
+				break
+			}
+		}
+	case yaml.SequenceNode:
+		for i := 0; i < len(dst.Content) && i < len(src.Content); i++ {
+			copyYAMLComments(dst.Content[i], src.Content[i])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cuebridge
+
+import (
+	"fmt"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+)
+
+// TestExportOptions tests indentation, key ordering, and YAML style
+func TestExportOptions(t *testing.T) {
+	value := cuecontext.New().CompileString(`{name: "app", spec: {replicas: 2, ports: [80, 443]}, env: "prod"}`)
+
+	tests := []struct {
+		name   string
+		format DataFormat
+		opts   ExportOptions
+		want   string
+	}{
+		{
+			name:   "yaml defaults",
+			format: FormatYAML,
+			want:   "name: app\nspec:\n  replicas: 2\n  ports:\n    - 80\n    - 443\nenv: prod\n",
+		},
+		{
+			name:   "yaml sorted with 4-space indent",
+			format: FormatYAML,
+			opts:   ExportOptions{Indent: 4, SortKeys: true},
+			want:   "env: prod\nname: app\nspec:\n    ports:\n        - 80\n        - 443\n    replicas: 2\n",
+		},
+		{
+			name:   "yaml flow style",
+			format: FormatYAML,
+			opts:   ExportOptions{YAMLFlowStyle: true},
+			want:   "{name: app, spec: {replicas: 2, ports: [80, 443]}, env: prod}\n",
+		},
+		{
+			name:   "json sorted",
+			format: FormatJSON,
+			opts:   ExportOptions{SortKeys: true},
+			want:   "{\n  \"env\": \"prod\",\n  \"name\": \"app\",\n  \"spec\": {\n    \"ports\": [\n      80,\n      443\n    ],\n    \"replicas\": 2\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exportValue(value, tt.format, tt.opts, nil)
+			if err != nil {
+				t.Fatalf("exportValue failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPreserveComments tests keeping YAML input comments in exported output
+func TestPreserveComments(t *testing.T) {
+	schema := `#Config: {name: string, replicas: *1 | int, ports: [...int]}`
+	validator := newTestValidatorWithOptions(t, schema, ValidationOptions{Export: ExportOptions{PreserveComments: true}})
+
+	base := "# Service config\n\n# the service name\nname: app # must be unique\nports: # exposed ports\n  - 80 # http\n  - 443\n"
+
+	t.Run("canonicalize", func(t *testing.T) {
+		_, data, err := validator.Canonicalize(ValidationInput{SourceType: SourceBytes, Data: []byte(base), Format: FormatYAML, Name: "base.yaml"})
+		if err != nil {
+			t.Fatalf("Canonicalize failed: %v", err)
+		}
+		want := "# Service config\n\n# the service name\nname: app # must be unique\nports: # exposed ports\n  - 80 # http\n  - 443\nreplicas: 1\n"
+		if string(data) != want {
+			t.Errorf("got:\n%s\nwant:\n%s", data, want)
+		}
+	})
+
+	t.Run("changed values drop their comments", func(t *testing.T) {
+		_, data, err := validator.ValidateLayered(
+			ValidationInput{SourceType: SourceBytes, Data: []byte(base), Format: FormatYAML, Name: "base.yaml"},
+			ValidationInput{SourceType: SourceBytes, Data: []byte("name: web\nports: [8080, 443]\n"), Format: FormatYAML, Name: "prod.yaml"},
+		)
+		if err != nil {
+			t.Fatalf("ValidateLayered failed: %v", err)
+		}
+		want := "# Service config\n\n# the service name\nname: web\nports: # exposed ports\n  - 8080\n  - 443\n"
+		if string(data) != want {
+			t.Errorf("got:\n%s\nwant:\n%s", data, want)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		validator := newTestValidator(t, schema)
+		_, data, err := validator.Canonicalize(ValidationInput{SourceType: SourceBytes, Data: []byte(base), Format: FormatYAML, Name: "base.yaml"})
+		if err != nil {
+			t.Fatalf("Canonicalize failed: %v", err)
+		}
+		if want := "name: app\nports:\n  - 80\n  - 443\nreplicas: 1\n"; string(data) != want {
+			t.Errorf("got:\n%s\nwant:\n%s", data, want)
+		}
+	})
+}
+
+// TestExportNumberKinds tests that exported numbers keep their kind, so
+// that the output validates against the schema the input did
+func TestExportNumberKinds(t *testing.T) {
+	schemaPath := writeTestSchema(t, `#Config: {ratio: float, scale: float, big: int, count: int}`)
+
+	tests := []struct {
+		name   string
+		format DataFormat
+		data   string
+	}{
+		{name: "json", format: FormatJSON, data: `{"ratio": 1.0, "scale": 1e3, "big": 12345678901234567890, "count": 3}`},
+		{name: "yaml", format: FormatYAML, data: "ratio: 1.0\nscale: 1e3\nbig: 12345678901234567890\ncount: 3\n"},
+	}
+
+	for _, tt := range tests {
+		for _, sortKeys := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s sorted %v", tt.name, sortKeys), func(t *testing.T) {
+				validator, err := NewBuilder(schemaPath, "#Config").
+					WithExport(ExportOptions{SortKeys: sortKeys}).
+					Build()
+				if err != nil {
+					t.Fatalf("Build failed: %v", err)
+				}
+
+				result, exported, err := validator.ValidateWithDefaults(ValidationInput{
+					SourceType: SourceBytes,
+					Data:       []byte(tt.data),
+					Format:     tt.format,
+					Name:       "config",
+				})
+				if err != nil || !result.Valid {
+					t.Fatalf("ValidateWithDefaults = %v, %v", result.Errors, err)
+				}
+
+				result, err = validator.Validate(ValidationInput{
+					SourceType: SourceBytes,
+					Data:       exported,
+					Format:     tt.format,
+					Name:       "exported",
+				})
+				if err != nil {
+					t.Fatalf("Validate failed: %v", err)
+				}
+				if !result.Valid {
+					t.Errorf("exported data fails the schema: %v\n%s", result.Errors, exported)
+				}
+			})
+		}
+	}
+}
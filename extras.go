@@ -0,0 +1,191 @@
+package cuebridge
+
+import (
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// withoutAllowedExtras returns parsed with the fields listed in
+// ValidationOptions.AllowedExtraFields removed wherever the definition
+// does not allow them, so that closed definitions accept them. Fields the
+// definition declares are kept and validated as usual.
+func (v *Validator) withoutAllowedExtras(configDef cue.Value, parsed parsedInput) parsedInput {
+	if len(v.options.AllowedExtraFields) == 0 {
+		return parsed
+	}
+	pruned, changed := pruneAllowedExtras(v.ctx, configDef, parsed.value, v.options.AllowedExtraFields)
+	if !changed {
+		return parsed
+	}
+
+	source := *parsed.source
+	source.value = pruned
+	parsed.value = pruned
+	parsed.source = &source
+	return parsed
+}
+
+// pruneAllowedExtras removes the fields of data matching allowed that
+// schema does not allow, recursing into structs and lists. Values without
+// such fields are returned as is; the others are rebuilt around the
+// original leaf values, which keep their positions.
+func pruneAllowedExtras(ctx *cue.Context, schema cue.Value, data cue.Value, allowed []string) (cue.Value, bool) {
+	if !schema.Exists() {
+		return data, false
+	}
+
+	switch data.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := data.Fields()
+		if err != nil {
+			return data, false
+		}
+		var sels []cue.Selector
+		var values []cue.Value
+		changed := false
+		for iter.Next() {
+			sel := iter.Selector()
+			if !schema.Allows(sel) && matchesAllowedField(sel.Unquoted(), allowed) {
+				changed = true
+				continue
+			}
+			value, fieldChanged := pruneAllowedExtras(ctx, lookupSchemaField(schema, sel), iter.Value(), allowed)
+			changed = changed || fieldChanged
+			sels = append(sels, sel)
+			values = append(values, value)
+		}
+		if !changed {
+			return data, false
+		}
+		pruned := ctx.CompileString("{}")
+		for i, sel := range sels {
+			pruned = pruned.FillPath(cue.MakePath(sel), values[i])
+		}
+		return pruned, true
+	case cue.ListKind:
+		iter, err := data.List()
+		if err != nil {
+			return data, false
+		}
+		var elems []cue.Value
+		changed := false
+		for iter.Next() {
+			elem, elemChanged := pruneAllowedExtras(ctx, lookupSchemaField(schema, iter.Selector()), iter.Value(), allowed)
+			changed = changed || elemChanged
+			elems = append(elems, elem)
+		}
+		if !changed {
+			return data, false
+		}
+		return ctx.NewList(elems...), true
+	default:
+		return data, false
+	}
+}
+
+// withAllowedExtras returns unified with the fields of data that
+// withoutAllowedExtras removed put back, so that values exported from it
+// keep them as written. Structs holding such fields, and the structs and
+// lists around them, are rebuilt open; everything else is kept as is.
+func (v *Validator) withAllowedExtras(configDef cue.Value, unified cue.Value, data cue.Value) cue.Value {
+	if len(v.options.AllowedExtraFields) == 0 {
+		return unified
+	}
+	restored, _ := restoreAllowedExtras(v.ctx, configDef, unified, data, v.options.AllowedExtraFields)
+	return restored
+}
+
+// restoreAllowedExtras adds the fields of data matching allowed that schema
+// does not allow to unified, recursing into structs and lists like
+// pruneAllowedExtras
+func restoreAllowedExtras(ctx *cue.Context, schema cue.Value, unified cue.Value, data cue.Value, allowed []string) (cue.Value, bool) {
+	if !schema.Exists() || !unified.Exists() {
+		return unified, false
+	}
+
+	switch data.IncompleteKind() {
+	case cue.StructKind:
+		dataFields, err := data.Fields()
+		if err != nil {
+			return unified, false
+		}
+		var extraSels []cue.Selector
+		var extraValues []cue.Value
+		replaced := map[string]cue.Value{}
+		for dataFields.Next() {
+			sel := dataFields.Selector()
+			if !schema.Allows(sel) && matchesAllowedField(sel.Unquoted(), allowed) {
+				extraSels = append(extraSels, sel)
+				extraValues = append(extraValues, dataFields.Value())
+				continue
+			}
+			value, changed := restoreAllowedExtras(ctx, lookupSchemaField(schema, sel), unified.LookupPath(cue.MakePath(sel)), dataFields.Value(), allowed)
+			if changed {
+				replaced[sel.String()] = value
+			}
+		}
+		if len(extraSels) == 0 && len(replaced) == 0 {
+			return unified, false
+		}
+
+		restored := ctx.CompileString("{}")
+		unifiedFields, err := unified.Fields()
+		if err != nil {
+			return unified, false
+		}
+		for unifiedFields.Next() {
+			sel := unifiedFields.Selector()
+			value := unifiedFields.Value()
+			if replacement, ok := replaced[sel.String()]; ok {
+				value = replacement
+			}
+			restored = restored.FillPath(cue.MakePath(sel), value)
+		}
+		for i, sel := range extraSels {
+			restored = restored.FillPath(cue.MakePath(sel), extraValues[i])
+		}
+		return restored, true
+	case cue.ListKind:
+		dataElems, err := data.List()
+		if err != nil {
+			return unified, false
+		}
+		unifiedElems, err := unified.List()
+		if err != nil {
+			return unified, false
+		}
+		var elems []cue.Value
+		changed := false
+		for unifiedElems.Next() {
+			elem := unifiedElems.Value()
+			if dataElems.Next() {
+				var elemChanged bool
+				elem, elemChanged = restoreAllowedExtras(ctx, lookupSchemaField(schema, unifiedElems.Selector()), elem, dataElems.Value(), allowed)
+				changed = changed || elemChanged
+			}
+			elems = append(elems, elem)
+		}
+		if !changed {
+			return unified, false
+		}
+		return ctx.NewList(elems...), true
+	default:
+		return unified, false
+	}
+}
+
+// matchesAllowedField reports whether name is listed in allowed, either
+// exactly or by a pattern with a trailing "*" (e.g., "x-*")
+func matchesAllowedField(name string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
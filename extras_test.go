@@ -0,0 +1,111 @@
+package cuebridge
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestAllowedExtraFields tests accepting listed extra fields under closed definitions
+func TestAllowedExtraFields(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	name:      string
+	metadata?: {owner: string}
+	ports: [...{port: int}]
+}`, ValidationOptions{AllowedExtraFields: []string{"x-*", "annotations"}})
+
+	tests := []struct {
+		name      string
+		data      string
+		wantPaths []string
+	}{
+		{name: "no extras", data: `{"name": "app", "ports": []}`},
+		{
+			name: "allowed extras at every level",
+			data: `{"name": "app", "x-team": "infra", "annotations": {"a": 1}, "ports": [{"port": 80, "x-note": "http"}]}`,
+		},
+		{
+			name:      "declared fields are still checked",
+			data:      `{"name": "app", "x-team": "infra", "metadata": {"owner": 1}, "ports": []}`,
+			wantPaths: []string{"metadata.owner"},
+		},
+		{
+			name:      "missing fields next to allowed extras",
+			data:      `{"x-team": "infra", "ports": []}`,
+			wantPaths: []string{"name"},
+		},
+		{
+			name:      "other extras are rejected",
+			data:      `{"name": "app", "y-team": "infra", "ports": [{"port": 80, "x-note": "http", "note": "http"}]}`,
+			wantPaths: []string{"ports.0.note", "y-team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			slices.Sort(paths)
+			if result.Valid != (len(tt.wantPaths) == 0) || !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("Valid = %v, error paths = %v, want %v", result.Valid, paths, tt.wantPaths)
+			}
+		})
+	}
+
+	t.Run("exports keep extras", func(t *testing.T) {
+		validator := newTestValidatorWithOptions(t, `#Config: {
+	name: string
+	port: *80 | int
+	ports: [...{port: int}]
+}`, ValidationOptions{AllowedExtraFields: []string{"x-*"}})
+		input := func(data string) ValidationInput {
+			return ValidationInput{SourceType: SourceBytes, Data: []byte(data), Format: FormatYAML, Name: "config.yaml"}
+		}
+
+		_, data, err := validator.Canonicalize(input("x-note: keep me\nname: a\nports:\n  - port: 1\n    x-tag: web\n"))
+		if err != nil {
+			t.Fatalf("Canonicalize failed: %v", err)
+		}
+		want := "name: a\nport: 80\nports:\n  - port: 1\n    x-tag: web\nx-note: keep me\n"
+		if string(data) != want {
+			t.Errorf("got:\n%s\nwant:\n%s", data, want)
+		}
+
+		_, first, err := validator.ValidateAndHash(input("name: a\nx-note: one\n"))
+		if err != nil {
+			t.Fatalf("ValidateAndHash failed: %v", err)
+		}
+		_, second, err := validator.ValidateAndHash(input("name: a\nx-note: two\n"))
+		if err != nil {
+			t.Fatalf("ValidateAndHash failed: %v", err)
+		}
+		if first == "" || first == second {
+			t.Errorf("hashes %q and %q, want distinct hashes for different extras", first, second)
+		}
+	})
+	t.Run("patches accept extras", func(t *testing.T) {
+		result, err := validator.ValidatePatch(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(`{"x-team": "infra", "ports": [{"x-note": "http"}], "note": "x"}`),
+			Format:     FormatJSON,
+			Name:       "patch.json",
+		})
+		if err != nil {
+			t.Fatalf("ValidatePatch failed: %v", err)
+		}
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Path != "note" {
+			t.Errorf("Valid = %v, errors = %v, want one error at note", result.Valid, result.Errors)
+		}
+	})
+}
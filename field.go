@@ -0,0 +1,99 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// ValidateField validates a single value against the constraint the
+// definition places on the field at path, without a whole document, e.g.
+// for checking form fields as they are edited. path is dotted like
+// ValidationError.Path (e.g., "spec.replicas" or "ports.0.port"); list
+// elements and fields matched by a pattern (e.g., [string]: int) take the
+// element or pattern constraint. The value is encoded as by encoding/json.
+//
+// Constraints that refer to other fields (e.g., max: >min) cannot be
+// resolved without them and are reported as incomplete. @msg attributes
+// replace error messages as in Validate. The result is named after path,
+// and its errors have paths but no line numbers.
+//
+// Returns an error if the schema has no field at path or the value cannot
+// be encoded.
+func (v *Validator) ValidateField(path string, value any) (ValidationResult, error) {
+	configDef, err := v.definition()
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	field := configDef
+	if path != "" {
+		for _, element := range strings.Split(path, ".") {
+			if field = lookupSchemaField(field, fieldSelector(field, element)); !field.Exists() {
+				return ValidationResult{}, fmt.Errorf("schema %s has no field %s", v.definitionName, path)
+			}
+		}
+	}
+
+	encoded := v.ctx.Encode(value)
+	if err := encoded.Err(); err != nil {
+		return ValidationResult{}, fmt.Errorf("encoding value for %s: %w", path, err)
+	}
+	if err := newInputLimits(v.options).check(encoded); err != nil {
+		return v.withProvenance(createErrorResult(path, err.Error())), nil
+	}
+
+	result := ValidationResult{Name: path, Valid: true, Errors: []ValidationError{}}
+	if err := field.Unify(encoded).Validate(cue.Concrete(true)); err != nil {
+		result = createValidationErrorResult(&sourceContext{name: path}, err)
+		relocateFieldErrors(result.Errors, err, field, path)
+	}
+	return v.withProvenance(result), nil
+}
+
+// relocateFieldErrors rewrites the paths of errors extracted from err, which
+// lead through the schema to field (e.g., "ports._.port" for a list element),
+// to lead through path instead (e.g., "ports.3.port"), and applies the @msg
+// attributes of the fields they occur in. Line and column refer to the
+// schema rather than an input and are cleared.
+func relocateFieldErrors(errs []ValidationError, err error, field cue.Value, path string) {
+	cueErrors := errors.Errors(err)
+	if len(cueErrors) != len(errs) {
+		return
+	}
+
+	prefix := strings.Split(path, ".")
+	if path == "" {
+		prefix = nil
+	}
+	depth := len(field.Path().Selectors())
+	for i, e := range cueErrors {
+		errPath := e.Path()
+		if len(errPath) < depth {
+			errPath = nil
+		} else {
+			errPath = errPath[depth:]
+		}
+		elements := append(prefix[:len(prefix):len(prefix)], errPath...)
+		errs[i].Path = formatPath(elements)
+		errs[i].Pointer = jsonPointer(elements)
+		errs[i].Line = 0
+		errs[i].Column = 0
+		if message, ok := messageAttribute(field.LookupPath(inputPath(errPath))); ok {
+			errs[i].Message = message
+		}
+	}
+}
+
+// fieldSelector converts an element of a dotted field path into the
+// selector for it in schema: a list index if the element is numeric and
+// schema is a list, and a field name otherwise (e.g., "123" in labels)
+func fieldSelector(schema cue.Value, element string) cue.Selector {
+	if index, err := strconv.Atoi(element); err == nil && schema.IncompleteKind() == cue.ListKind {
+		return cue.Index(index)
+	}
+	return cue.Str(element)
+}
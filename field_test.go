@@ -0,0 +1,82 @@
+package cuebridge
+
+import (
+	"testing"
+)
+
+// TestValidateField tests validating a single value against a field's constraint
+func TestValidateField(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	port:  int & >0 & <65536
+	name?: =~"^[a-z]+$"
+	spec: replicas: *1 | int & >=1
+	ports: [...{port: int}]
+	labels: [string]: string
+	limits: {min: int, max: int & >min}
+	level:  =~"^[a-z]+$" @msg("level must be lowercase")
+	owners: [...{id: int & >0 @msg("owner ids are positive")}]
+}`)
+
+	tests := []struct {
+		name        string
+		path        string
+		value       any
+		wantValid   bool
+		wantPaths   []string
+		wantMessage string
+		wantErr     bool
+	}{
+		{name: "valid", path: "port", value: 8080, wantValid: true},
+		{name: "out of range", path: "port", value: 70000, wantPaths: []string{"port"}},
+		{name: "wrong type", path: "port", value: "8080", wantPaths: []string{"port"}},
+		{name: "optional field", path: "name", value: "App", wantPaths: []string{"name"}},
+		{name: "nested field", path: "spec.replicas", value: 0, wantPaths: []string{"spec.replicas", "spec.replicas", "spec.replicas"}},
+		{name: "list element", path: "ports.0.port", value: 80, wantValid: true},
+		{name: "whole list element", path: "ports.3", value: map[string]any{"port": "80"}, wantPaths: []string{"ports.3.port"}},
+		{name: "pattern field", path: "labels.team", value: 1, wantPaths: []string{"labels.team"}},
+		{name: "struct", path: "limits", value: map[string]any{"min": 2, "max": 1}, wantPaths: []string{"limits.max"}},
+		{name: "reference to another field", path: "limits.max", value: 5, wantPaths: []string{"limits.max"}},
+		{name: "numeric pattern field", path: "labels.123", value: "x", wantValid: true},
+		{name: "numeric pattern field checked", path: "labels.123", value: 1, wantPaths: []string{"labels.123"}},
+		{name: "message attribute", path: "level", value: "Info", wantPaths: []string{"level"}, wantMessage: "level must be lowercase"},
+		{name: "nested message attribute", path: "owners.2", value: map[string]any{"id": 0}, wantPaths: []string{"owners.2.id"}, wantMessage: "owner ids are positive"},
+		{name: "unknown field", path: "prot", value: 80, wantErr: true},
+		{name: "unencodable value", path: "port", value: make(chan int), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.ValidateField(tt.path, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ValidateField() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateField() error = %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Name != tt.path {
+				t.Errorf("Name = %q, want %q", result.Name, tt.path)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if len(paths) != len(tt.wantPaths) {
+				t.Fatalf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+			for i := range paths {
+				if paths[i] != tt.wantPaths[i] {
+					t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+				}
+			}
+			if tt.wantMessage != "" && result.Errors[0].Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", result.Errors[0].Message, tt.wantMessage)
+			}
+		})
+	}
+}
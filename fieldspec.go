@@ -0,0 +1,110 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/format"
+)
+
+// FieldSpec describes a leaf field of the validator's definition,
+// e.g. for generating forms or documentation from the schema.
+type FieldSpec struct {
+	// Path is the field's dotted path (e.g., "spec.replicas"). List
+	// elements are numbered for fixed positions (e.g., "pair.0") and "*"
+	// for any element (e.g., "ports.*"), as are fields matched by a
+	// pattern constraint (e.g., "labels.*").
+	Path string
+	// Kind is the field's expected type (e.g., "int", "int|string")
+	Kind string
+	// Required reports whether input must set the field: it is neither
+	// optional nor has a default or a concrete value
+	Required bool
+	// Default is the field's default in CUE syntax (e.g., "8080" or
+	// "\"info\""), or "" if it has none
+	Default string
+	// Constraint is the field's constraint in CUE syntax as written in the
+	// schema (e.g., "int & >0" or "#Port | *8080")
+	Constraint string
+}
+
+// FieldSpecs lists the leaf fields of the validator's definition in schema
+// order, descending into nested structs and list elements. Structs and
+// lists themselves are not listed, only the fields and elements they
+// contain. Elements of a recursive list (e.g., children: [...#Node]) are
+// not expanded.
+// Returns an error if the definition is not a struct.
+func (v *Validator) FieldSpecs() ([]FieldSpec, error) {
+	configDef, err := v.definition()
+	if err != nil {
+		return nil, err
+	}
+	if configDef.IncompleteKind() != cue.StructKind {
+		return nil, fmt.Errorf("%s is not a struct", v.definitionName)
+	}
+
+	var specs []FieldSpec
+	collectFieldSpecs(configDef, nil, true, &specs)
+	return specs, nil
+}
+
+// collectFieldSpecs appends a FieldSpec for value if it is a leaf, or for
+// the leaves it contains otherwise. required is false if the field or one
+// of its parents is optional.
+func collectFieldSpecs(value cue.Value, path []string, required bool, specs *[]FieldSpec) {
+	if !value.Exists() || value.Err() != nil {
+		return
+	}
+
+	switch value.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := value.Fields(cue.Optional(true))
+		if err != nil {
+			return
+		}
+		for iter.Next() {
+			fieldPath := append(path[:len(path):len(path)], iter.Selector().Unquoted())
+			collectFieldSpecs(iter.Value(), fieldPath, required && !iter.IsOptional(), specs)
+		}
+		if pattern := value.LookupPath(cue.MakePath(cue.AnyString)); pattern.Exists() {
+			collectFieldSpecs(pattern, append(path[:len(path):len(path)], "*"), false, specs)
+		}
+	case cue.ListKind:
+		if iter, err := value.List(); err == nil {
+			for iter.Next() {
+				elemPath := append(path[:len(path):len(path)], iter.Selector().String())
+				collectFieldSpecs(iter.Value(), elemPath, required, specs)
+			}
+		}
+		if elem := value.LookupPath(cue.MakePath(cue.AnyIndex)); elem.Exists() {
+			collectFieldSpecs(elem, append(path[:len(path):len(path)], "*"), false, specs)
+		}
+	default:
+		*specs = append(*specs, newFieldSpec(value, path, required))
+	}
+}
+
+// newFieldSpec describes the leaf value at path
+func newFieldSpec(value cue.Value, path []string, required bool) FieldSpec {
+	spec := FieldSpec{
+		Path:       formatPath(path),
+		Kind:       value.IncompleteKind().String(),
+		Constraint: formatSyntax(value),
+	}
+
+	defaultValue, hasDefault := value.Default()
+	if hasDefault {
+		spec.Default = formatSyntax(defaultValue)
+	}
+	spec.Required = required && !hasDefault && !value.IsConcrete()
+	return spec
+}
+
+// formatSyntax formats value as CUE, keeping references such as #Port
+func formatSyntax(value cue.Value) string {
+	source, err := format.Node(value.Syntax(cue.Raw()))
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(source)
+}
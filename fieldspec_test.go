@@ -0,0 +1,58 @@
+package cuebridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFieldSpecs tests listing the leaf fields of a definition
+func TestFieldSpecs(t *testing.T) {
+	validator := newTestValidator(t, `#Port: int & >0
+#Node: {name: string, children: [...#Node]}
+#Config: {
+	name:  string
+	port:  #Port | *8080
+	kind:  "Service"
+	mode?: "fast" | "safe"
+	spec: {
+		replicas: *1 | int
+		image!:   string
+	}
+	ports: [...int]
+	pair: [string, ...int]
+	items?: [...{id: int}]
+	labels: [string]: string
+	tree: #Node
+}`)
+
+	specs, err := validator.FieldSpecs()
+	if err != nil {
+		t.Fatalf("FieldSpecs failed: %v", err)
+	}
+
+	want := []FieldSpec{
+		{Path: "name", Kind: "string", Required: true, Constraint: "string"},
+		{Path: "port", Kind: "int", Default: "8080", Constraint: "#Port | *8080"},
+		{Path: "kind", Kind: "string", Constraint: `"Service"`},
+		{Path: "mode", Kind: "string", Constraint: `"fast" | "safe"`},
+		{Path: "spec.replicas", Kind: "int", Default: "1", Constraint: "*1 | int"},
+		{Path: "spec.image", Kind: "string", Required: true, Constraint: "string"},
+		{Path: "ports.*", Kind: "int", Constraint: "int"},
+		{Path: "pair.0", Kind: "string", Required: true, Constraint: "string"},
+		{Path: "pair.*", Kind: "int", Constraint: "int"},
+		{Path: "items.*.id", Kind: "int", Constraint: "int"},
+		{Path: "labels.*", Kind: "string", Constraint: "string"},
+		{Path: "tree.name", Kind: "string", Required: true, Constraint: "string"},
+	}
+	if !reflect.DeepEqual(specs, want) {
+		t.Errorf("got:\n%+v\nwant:\n%+v", specs, want)
+	}
+}
+
+// TestFieldSpecsNotStruct tests rejecting a definition that is not a struct
+func TestFieldSpecsNotStruct(t *testing.T) {
+	validator := newTestValidator(t, `#Config: [...int]`)
+	if _, err := validator.FieldSpecs(); err == nil {
+		t.Error("expected an error for a list definition")
+	}
+}
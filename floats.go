@@ -0,0 +1,157 @@
+package cuebridge
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// DefaultFloatEpsilon is the relative tolerance used when
+// ValidationOptions.FloatTolerance is set and FloatEpsilon is 0.
+const DefaultFloatEpsilon = 1e-9
+
+const floatPattern = `(-?[0-9]+(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?)`
+
+// floatConflictPattern matches two unequal numbers of the same kind
+// (e.g., "conflicting values 0.30000000000000004 and 0.3")
+var floatConflictPattern = regexp.MustCompile(`conflicting values ` + floatPattern + ` and ` + floatPattern + `$`)
+
+// floatBoundPattern matches a number outside an inclusive bound
+// (e.g., "invalid value 1.0000000000000002 (out of bound <=1.0)")
+var floatBoundPattern = regexp.MustCompile(`invalid value ` + floatPattern + ` \(out of bound (?:<=|>=)` + floatPattern + `\)$`)
+
+// floatEpsilon returns the tolerance for float comparisons,
+// or 0 if FloatTolerance is not set
+func (v *Validator) floatEpsilon() float64 {
+	switch {
+	case !v.options.FloatTolerance:
+		return 0
+	case v.options.FloatEpsilon > 0:
+		return v.options.FloatEpsilon
+	default:
+		return DefaultFloatEpsilon
+	}
+}
+
+// withoutNearFloatErrors drops errors that only compare two numbers within
+// epsilon of each other: an equality constraint (e.g., 0.3 against
+// 0.30000000000000004) or an inclusive bound (e.g., <=1.0 against
+// 1.0000000000000002). It returns nil if no errors remain.
+func withoutNearFloatErrors(err error, epsilon float64) error {
+	var remaining errors.Error
+	for _, e := range errors.Errors(err) {
+		if !isNearFloatError(e.Error(), epsilon) {
+			remaining = errors.Append(remaining, e)
+		}
+	}
+	if remaining == nil {
+		return nil
+	}
+	return remaining
+}
+
+// isNearFloatError reports whether message compares two numbers within
+// epsilon, at least one of them a float
+func isNearFloatError(message string, epsilon float64) bool {
+	_, _, ok := nearFloatOperands(message, epsilon)
+	return ok
+}
+
+// nearFloatOperands returns the two numbers message compares, if they are
+// within epsilon of each other and at least one of them is a float. For a
+// bound, the first is the value and the second the bound. Integers are
+// compared exactly, as large ones lose precision as float64.
+func nearFloatOperands(message string, epsilon float64) (string, string, bool) {
+	match := floatConflictPattern.FindStringSubmatch(message)
+	if match == nil {
+		match = floatBoundPattern.FindStringSubmatch(message)
+	}
+	if match == nil || (!isFloatLiteral(match[1]) && !isFloatLiteral(match[2])) {
+		return "", "", false
+	}
+
+	a, errA := strconv.ParseFloat(match[1], 64)
+	b, errB := strconv.ParseFloat(match[2], 64)
+	if errA != nil || errB != nil {
+		return "", "", false
+	}
+	// Relative to the magnitude of the numbers, but at least absolute near zero
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	if math.Abs(a-b) > epsilon*scale {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// isFloatLiteral reports whether a number literal is a float (e.g., 0.3 or
+// 1e-9) rather than an int
+func isFloatLiteral(literal string) bool {
+	return strings.ContainsAny(literal, ".eE")
+}
+
+// withSchemaFloats returns data with each number the schema only accepts
+// through the float tolerance replaced by the schema's number (the value
+// it conflicts with, or the bound it exceeds), so that data unified with
+// configDef has no conflicts left and can be exported
+func withSchemaFloats(ctx *cue.Context, configDef cue.Value, data cue.Value, epsilon float64) cue.Value {
+	err := configDef.Unify(data).Validate(cue.Concrete(true))
+	for _, e := range errors.Errors(err) {
+		a, b, ok := nearFloatOperands(e.Error(), epsilon)
+		if !ok {
+			continue
+		}
+		path := inputPath(e.Path())
+		current := data.LookupPath(path)
+		// In a conflict, the operand that is not the input's number is the schema's
+		literal := b
+		if number, err := current.Float64(); err == nil {
+			if first, _ := strconv.ParseFloat(a, 64); number != first {
+				literal = a
+			}
+		}
+		if current.Kind() == cue.FloatKind && !isFloatLiteral(literal) {
+			literal += ".0"
+		}
+		data = replaceValue(ctx, data, path.Selectors(), ctx.CompileString(literal))
+	}
+	return data
+}
+
+// replaceValue returns data with the value at path replaced by
+// replacement. Other values, and the order of fields, are kept.
+func replaceValue(ctx *cue.Context, data cue.Value, path []cue.Selector, replacement cue.Value) cue.Value {
+	if len(path) == 0 {
+		return replacement
+	}
+
+	switch data.IncompleteKind() {
+	case cue.StructKind:
+		replaced := ctx.CompileString("{}")
+		iter, _ := data.Fields()
+		for iter.Next() {
+			value := iter.Value()
+			if iter.Selector().String() == path[0].String() {
+				value = replaceValue(ctx, value, path[1:], replacement)
+			}
+			replaced = replaced.FillPath(cue.MakePath(iter.Selector()), value)
+		}
+		return replaced
+	case cue.ListKind:
+		var elements []cue.Value
+		iter, _ := data.List()
+		for iter.Next() {
+			value := iter.Value()
+			if iter.Selector().String() == path[0].String() {
+				value = replaceValue(ctx, value, path[1:], replacement)
+			}
+			elements = append(elements, value)
+		}
+		return ctx.NewList(elements...)
+	default:
+		return data
+	}
+}
@@ -0,0 +1,114 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFloatTolerance tests accepting floats within epsilon of the schema
+func TestFloatTolerance(t *testing.T) {
+	schema := `#Config: {
+	ratio?: 0.3
+	share?: float & <=1.0
+	count?: 3
+	list?: [0.1]
+	id?:    1000000000000
+	n?:     int & <=1000000000000
+}`
+
+	tests := []struct {
+		name      string
+		data      string
+		tolerance bool
+		epsilon   float64
+		wantPaths []string
+	}{
+		{name: "exact comparison by default", data: `{"ratio": 0.30000000000000004}`, wantPaths: []string{"ratio"}},
+		{name: "near-equal value", data: `{"ratio": 0.30000000000000004}`, tolerance: true},
+		{name: "near-equal list element", data: `{"list": [0.10000000000000001]}`, tolerance: true},
+		{name: "near inclusive bound", data: `{"share": 1.0000000000000002}`, tolerance: true},
+		{name: "clearly different value", data: `{"ratio": 0.31, "share": 1.1}`, tolerance: true, wantPaths: []string{"ratio", "share"}},
+		{name: "custom epsilon", data: `{"ratio": 0.31}`, tolerance: true, epsilon: 0.1},
+		{name: "int and float still mismatch", data: `{"count": 3.0000000001}`, tolerance: true, wantPaths: []string{"count"}},
+		{name: "ints compared exactly", data: `{"id": 1000000000001}`, tolerance: true, wantPaths: []string{"id"}},
+		{name: "int bounds compared exactly", data: `{"n": 1000000000001}`, tolerance: true, wantPaths: []string{"n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, ValidationOptions{
+				FloatTolerance: tt.tolerance,
+				FloatEpsilon:   tt.epsilon,
+			})
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+
+			if result.Valid != (len(tt.wantPaths) == 0) {
+				t.Fatalf("Valid = %v, errors: %v", result.Valid, result.Errors)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			for _, want := range tt.wantPaths {
+				found := false
+				for _, path := range paths {
+					found = found || path == want
+				}
+				if !found {
+					t.Errorf("no error at %s, got paths %v", want, paths)
+				}
+			}
+		})
+	}
+}
+
+// TestFloatToleranceExport tests exporting inputs accepted through the float
+// tolerance: the schema's numbers take the place of the near ones
+func TestFloatToleranceExport(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	ratio:    0.3
+	share:    float & <=1.0
+	replicas: *1 | int
+}`, ValidationOptions{FloatTolerance: true})
+
+	input := ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"share": 1.0000000000000002, "ratio": 0.30000000000000004}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	}
+	want := "{\n  \"ratio\": 0.3,\n  \"replicas\": 1,\n  \"share\": 1.0\n}\n"
+
+	result, data, err := validator.Canonicalize(input)
+	if err != nil {
+		t.Fatalf("Canonicalize failed: %v", err)
+	}
+	if !result.Valid || string(data) != want {
+		t.Errorf("Canonicalize = %v, %q, want valid %q (errors: %v)", result.Valid, data, want, result.Errors)
+	}
+
+	result, data, err = validator.ValidateWithDefaults(input)
+	if err != nil {
+		t.Fatalf("ValidateWithDefaults failed: %v", err)
+	}
+	if !result.Valid || !strings.Contains(string(data), `"ratio": 0.3,`) {
+		t.Errorf("ValidateWithDefaults = %v, %q, want valid with the schema's ratio (errors: %v)", result.Valid, data, result.Errors)
+	}
+
+	result, data, patched, err := validator.ValidateAndPatch(input)
+	if err != nil {
+		t.Fatalf("ValidateAndPatch failed: %v", err)
+	}
+	if !result.Valid || !patched || !strings.Contains(string(data), `"replicas": 1`) {
+		t.Errorf("ValidateAndPatch = %v, %v, %q, want valid patched data (errors: %v)", result.Valid, patched, data, result.Errors)
+	}
+}
@@ -0,0 +1,65 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+)
+
+// ValidateForeignValue validates a CUE value that may come from a different
+// cue.Context than the validator's, e.g. one built by a larger CUE program.
+// Unifying values from different contexts panics, so a foreign value is
+// first re-encoded into the validator's context through its syntax.
+//
+// Re-encoding costs time proportional to the size of the value. The copy
+// keeps no source positions, so the line and column of an error, when set,
+// point into the schema rather than the value. References to other parts
+// of the original program are resolved into the copy. Values already in
+// the validator's context are used as they are, positions included.
+//
+// The value is checked against the validator's definition, or the one the
+// discriminator field selects; there is no ValidationInput, so a different
+// definition or input path cannot be requested. Like Validate, the result
+// records its duration and is logged if a logger is configured.
+//
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateForeignValue(value cue.Value, name string) (ValidationResult, error) {
+	return v.timeValidation(name, func() (ValidationResult, error) {
+		if value.Context() != v.ctx {
+			imported, err := v.importValue(value)
+			if err != nil {
+				return ValidationResult{}, err
+			}
+			value = imported
+		}
+
+		source := &sourceContext{name: name, filename: name, value: value}
+		if value.Err() != nil {
+			return v.withProvenance(createValidationErrorResult(source, value.Err())), nil
+		}
+
+		parsed := parsedInput{value: value, source: source}
+		definitionName, configDef, failed, err := v.inputDefinition(ValidationInput{Name: name}, &parsed)
+		if err != nil {
+			return ValidationResult{}, err
+		}
+		if failed != nil {
+			return *failed, nil
+		}
+
+		return v.checkInput(definitionName, configDef, parsed), nil
+	})
+}
+
+// importValue rebuilds a value from another context in the validator's context
+func (v *Validator) importValue(value cue.Value) (cue.Value, error) {
+	switch node := value.Syntax().(type) {
+	case *ast.File:
+		return v.ctx.BuildFile(node), nil
+	case ast.Expr:
+		return v.ctx.BuildExpr(node), nil
+	default:
+		return cue.Value{}, fmt.Errorf("cannot import value of type %T", node)
+	}
+}
@@ -0,0 +1,72 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+// TestValidateForeignValue tests validating values from another cue.Context
+func TestValidateForeignValue(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string, replicas: int & >=1, tags?: [...string]}`)
+
+	program := cuecontext.New().CompileString(`
+defaultName: "app"
+good: {name: defaultName, replicas: 2, tags: [defaultName, "web"]}
+bad: {name: defaultName, replicas: 0}
+`)
+
+	tests := []struct {
+		path      string
+		wantValid bool
+	}{
+		{path: "good", wantValid: true},
+		{path: "bad", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			value := program.LookupPath(cue.ParsePath(tt.path))
+			result, err := validator.ValidateForeignValue(value, tt.path)
+			if err != nil {
+				t.Fatalf("ValidateForeignValue failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Name != tt.path {
+				t.Errorf("Name = %q, want %q", result.Name, tt.path)
+			}
+		})
+	}
+}
+
+// TestValidateForeignValueLogging tests that foreign values are timed and
+// logged like other inputs
+func TestValidateForeignValueLogging(t *testing.T) {
+	var output bytes.Buffer
+	validator := newTestValidatorWithOptions(t, `#Config: {replicas: int & >=1}`, ValidationOptions{
+		Logger: slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	value := cuecontext.New().CompileString(`replicas: 0`)
+	result, err := validator.ValidateForeignValue(value, "program")
+	if err != nil {
+		t.Fatalf("ValidateForeignValue failed: %v", err)
+	}
+	if result.Valid || result.Duration <= 0 {
+		t.Errorf("got Valid = %v, Duration = %v, want invalid with a duration", result.Valid, result.Duration)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON log record, got %q: %v", output.String(), err)
+	}
+	if record["input"] != "program" || record["valid"] != false {
+		t.Errorf("unexpected log record: %v", record)
+	}
+}
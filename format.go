@@ -1,53 +1,267 @@
 package cuebridge
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+// FormatOptions customizes the labels used by FormatResultsWith,
+// e.g. for localization. Empty fields use the FormatResults defaults.
+type FormatOptions struct {
+	// SuccessLabel follows the name of a valid input (default "ok")
+	SuccessLabel string
+	// FailureLabel precedes the name of an invalid input (default "FAIL")
+	FailureLabel string
+	// ErrorPrefix starts each error line (default two spaces)
+	ErrorPrefix string
+}
+
+// withDefaults returns opts with empty fields set to their defaults
+func (opts FormatOptions) withDefaults() FormatOptions {
+	if opts.SuccessLabel == "" {
+		opts.SuccessLabel = "ok"
+	}
+	if opts.FailureLabel == "" {
+		opts.FailureLabel = "FAIL"
+	}
+	if opts.ErrorPrefix == "" {
+		opts.ErrorPrefix = "  "
+	}
+	return opts
+}
+
 // FormatResults formats validation results into a human-readable string.
 //
 // Output format:
 //
 //	config.yaml: ok
-//	config.json: FAIL
+//	FAIL: config.json
 //	  line 5, field "replicas": value 0 does not satisfy constraint >=1
 func FormatResults(results []ValidationResult) string {
+	return FormatResultsWith(results, FormatOptions{})
+}
+
+// FormatResultsWith formats validation results like FormatResults,
+// with the labels set by opts.
+func FormatResultsWith(results []ValidationResult, opts FormatOptions) string {
 	var output strings.Builder
 
+	// Writing to a strings.Builder never fails
+	_ = writeResults(&output, results, opts.withDefaults())
+
+	return output.String()
+}
+
+// WriteResults writes validation results to w in the FormatResults format.
+// Each result is written as soon as it is formatted, so large batches
+// are not buffered in memory.
+func WriteResults(w io.Writer, results []ValidationResult) error {
+	return writeResults(w, results, FormatOptions{}.withDefaults())
+}
+
+// writeResults writes validation results with the given labels
+func writeResults(w io.Writer, results []ValidationResult, opts FormatOptions) error {
 	for _, result := range results {
-		formatSingleResult(&output, result)
+		if err := writeSingleResult(w, result, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSingleResult writes a single validation result
+func writeSingleResult(w io.Writer, result ValidationResult, opts FormatOptions) error {
+	var err error
+	if result.Valid {
+		_, err = fmt.Fprintf(w, "%s: %s\n", result.Name, opts.SuccessLabel)
+	} else {
+		_, err = fmt.Fprintf(w, "%s: %s\n", opts.FailureLabel, result.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, e := range result.Errors {
+		if err := writeError(w, e, opts.ErrorPrefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeError writes a single validation error
+func writeError(w io.Writer, err ValidationError, prefix string) error {
+	if err.Severity == SeverityWarning {
+		err.Message = "warning: " + err.Message
+	}
+
+	_, writeErr := fmt.Fprintf(w, "%s%s\n", prefix, err.Error())
+	return writeErr
+}
+
+// FormatAnnotated echoes input with the result's errors inserted after the
+// lines they refer to, like a compiler's annotated source:
+//
+//	name: app
+//	replicas: 0
+//	>>> error: field "replicas": value 0 does not satisfy >=1
+//
+// Several errors on one line are listed in result order. Errors without a
+// line, or with a line past the end of input, follow the last line.
+// Enable ValidationOptions.PreferInputPositions so that line numbers
+// refer to the input rather than the schema where possible.
+func FormatAnnotated(input []byte, result ValidationResult) string {
+	byLine := map[int][]ValidationError{}
+	for _, e := range result.Errors {
+		byLine[e.Line] = append(byLine[e.Line], e)
+	}
+
+	lines := strings.Split(string(input), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var output strings.Builder
+	for i, line := range lines {
+		output.WriteString(strings.TrimRight(line, "\r") + "\n")
+		writeAnnotations(&output, byLine[i+1])
+		delete(byLine, i+1)
+	}
+
+	// Remaining errors have no line in the input
+	remaining := slices.Sorted(maps.Keys(byLine))
+	for _, line := range remaining {
+		writeAnnotations(&output, byLine[line])
 	}
 
 	return output.String()
 }
 
-// formatSingleResult formats a single validation result
-func formatSingleResult(output *strings.Builder, result ValidationResult) {
-	if result.Valid {
-		fmt.Fprintf(output, "%s: ok\n", result.Name)
-		return
+// writeAnnotations writes an annotation line for each error
+func writeAnnotations(output *strings.Builder, errs []ValidationError) {
+	for _, e := range errs {
+		e.Line = 0
+		fmt.Fprintf(output, ">>> %s: %s\n", e.Severity, e.Error())
+	}
+}
+
+// FormatResultsJSON formats validation results as an indented JSON array.
+//
+// Each error object has "line", "column", "path", "message", and "severity"
+// keys, plus "source_line" when source line capture is enabled and the
+// line is available.
+func FormatResultsJSON(results []ValidationResult) ([]byte, error) {
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(normalizeResults(results)); err != nil {
+		return nil, fmt.Errorf("encoding results: %w", err)
+	}
+	return output.Bytes(), nil
+}
+
+// FormatResultsCSV formats validation results as CSV with a header row and
+// the columns name, valid, line, column, path, and message, e.g. for review
+// in a spreadsheet. Each error is a row; a result without errors is a
+// single row with empty error columns. Unknown lines and columns (0) are
+// left empty. Cells starting with a character that spreadsheets read as a
+// formula (=, +, -, @, tab, or carriage return) are prefixed with a single
+// quote, so that input-controlled text is not evaluated.
+func FormatResultsCSV(results []ValidationResult) ([]byte, error) {
+	var output bytes.Buffer
+	writer := csv.NewWriter(&output)
+	if err := writer.Write([]string{"name", "valid", "line", "column", "path", "message"}); err != nil {
+		return nil, fmt.Errorf("encoding results: %w", err)
+	}
+	for _, result := range results {
+		valid := strconv.FormatBool(result.Valid)
+		if len(result.Errors) == 0 {
+			if err := writer.Write([]string{csvText(result.Name), valid, "", "", "", ""}); err != nil {
+				return nil, fmt.Errorf("encoding result %s: %w", result.Name, err)
+			}
+			continue
+		}
+		for _, e := range result.Errors {
+			record := []string{csvText(result.Name), valid, csvPosition(e.Line), csvPosition(e.Column), csvText(e.Path), csvText(e.Message)}
+			if err := writer.Write(record); err != nil {
+				return nil, fmt.Errorf("encoding result %s: %w", result.Name, err)
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("encoding results: %w", err)
 	}
+	return output.Bytes(), nil
+}
+
+// csvText guards a text cell against formula injection by prefixing text
+// that starts with a formula character with a single quote
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// csvPosition formats a line or column number, leaving 0 (unknown) empty
+func csvPosition(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// WriteResultsJSONL writes each result received from results to w as a
+// compact JSON object on its own line (JSON Lines), as soon as it arrives,
+// until the channel is closed. Results are encoded like FormatResultsJSON.
+// After each line, w is flushed if it has a Flush method (e.g., a
+// *bufio.Writer or an http.ResponseWriter), so consumers see progress.
+//
+// On a write error, WriteResultsJSONL returns without draining results;
+// with ValidateDirStream, cancel its context to stop the walk.
+func WriteResultsJSONL(w io.Writer, results <-chan ValidationResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for result := range results {
+		if err := encoder.Encode(normalizeResults([]ValidationResult{result})[0]); err != nil {
+			return fmt.Errorf("writing result %s: %w", result.Name, err)
+		}
+		if err := flushWriter(w); err != nil {
+			return fmt.Errorf("writing result %s: %w", result.Name, err)
+		}
+	}
+	return nil
+}
 
-	fmt.Fprintf(output, "FAIL: %s\n", result.Name)
-	for _, err := range result.Errors {
-		formatError(output, err)
+// flushWriter flushes w if it buffers output
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
 	}
+	return nil
 }
 
-// formatError formats a single validation error
-func formatError(output *strings.Builder, err ValidationError) {
-	switch {
-	case err.Line > 0 && err.Path != "":
-		fmt.Fprintf(output, "  line %d, field \"%s\": %s\n",
-			err.Line, err.Path, err.Message)
-	case err.Line > 0:
-		fmt.Fprintf(output, "  line %d: %s\n",
-			err.Line, err.Message)
-	case err.Path != "":
-		fmt.Fprintf(output, "  field \"%s\": %s\n",
-			err.Path, err.Message)
-	default:
-		fmt.Fprintf(output, "  %s\n", err.Message)
+// normalizeResults returns copies of results whose nil Errors are replaced
+// by empty slices, so that they encode as [] rather than null
+func normalizeResults(results []ValidationResult) []ValidationResult {
+	normalized := make([]ValidationResult, len(results))
+	for i, result := range results {
+		if result.Errors == nil {
+			result.Errors = []ValidationError{}
+		}
+		normalized[i] = result
 	}
+	return normalized
 }
@@ -0,0 +1,251 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// TestWriteResults tests that streaming output matches FormatResults
+func TestWriteResults(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "ok.yaml", Valid: true},
+		{Name: "bad.json", Valid: false, Errors: []ValidationError{
+			{Line: 5, Path: "replicas", Message: "invalid value 0"},
+			{Message: "failed to parse"},
+		}},
+	}
+
+	var output bytes.Buffer
+	if err := WriteResults(&output, results); err != nil {
+		t.Fatalf("WriteResults failed: %v", err)
+	}
+
+	want := "ok.yaml: ok\n" +
+		"FAIL: bad.json\n" +
+		"  line 5, field \"replicas\": invalid value 0\n" +
+		"  failed to parse\n"
+	if output.String() != want {
+		t.Errorf("WriteResults output:\n%s\nwant:\n%s", output.String(), want)
+	}
+	if got := FormatResults(results); got != want {
+		t.Errorf("FormatResults output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestFormatResultsWith tests custom labels in text output
+func TestFormatResultsWith(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "ok.yaml", Valid: true},
+		{Name: "bad.json", Valid: false, Errors: []ValidationError{
+			{Line: 5, Path: "replicas", Message: "invalid value 0"},
+		}},
+	}
+
+	got := FormatResultsWith(results, FormatOptions{
+		SuccessLabel: "OK",
+		FailureLabel: "FEHLER",
+		ErrorPrefix:  "    - ",
+	})
+	want := "ok.yaml: OK\n" +
+		"FEHLER: bad.json\n" +
+		"    - line 5, field \"replicas\": invalid value 0\n"
+	if got != want {
+		t.Errorf("FormatResultsWith output:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got, want := FormatResultsWith(results, FormatOptions{}), FormatResults(results); got != want {
+		t.Errorf("zero FormatOptions output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestFormatAnnotated tests echoing input with inline error annotations
+func TestFormatAnnotated(t *testing.T) {
+	input := []byte("name: app\nreplicas: 0\nport: 0\n")
+	result := ValidationResult{Name: "config.yaml", Errors: []ValidationError{
+		{Line: 2, Path: "replicas", Message: "invalid value 0"},
+		{Line: 3, Path: "port", Message: "invalid value 0"},
+		{Line: 2, Path: "replicas", Message: "field is deprecated", Severity: SeverityWarning},
+		{Message: "failed to parse"},
+		{Line: 10, Message: "beyond the input"},
+	}}
+
+	want := "name: app\n" +
+		"replicas: 0\n" +
+		">>> error: field \"replicas\": invalid value 0\n" +
+		">>> warning: field \"replicas\": field is deprecated\n" +
+		"port: 0\n" +
+		">>> error: field \"port\": invalid value 0\n" +
+		">>> error: failed to parse\n" +
+		">>> error: beyond the input\n"
+	if got := FormatAnnotated(input, result); got != want {
+		t.Errorf("FormatAnnotated output:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := FormatAnnotated(input, ValidationResult{Valid: true}); got != string(input) {
+		t.Errorf("valid result output:\n%s\nwant the input unchanged", got)
+	}
+}
+
+// TestFormatResultsJSONSourceLines tests source lines in JSON output
+func TestFormatResultsJSONSourceLines(t *testing.T) {
+	schema := `#Config: {name: string, replicas: int & >=1}`
+	validator := newTestValidatorWithOptions(t, schema, ValidationOptions{CaptureSourceLines: true})
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nreplicas: 0\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	output, err := FormatResultsJSON([]ValidationResult{result})
+	if err != nil {
+		t.Fatalf("FormatResultsJSON failed: %v", err)
+	}
+
+	var decoded []ValidationResult
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(decoded) != 1 || len(decoded[0].Errors) != 1 {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if got := decoded[0].Errors[0].SourceLine; got != "replicas: 0" {
+		t.Errorf("SourceLine = %q, want %q", got, "replicas: 0")
+	}
+
+	// Omitted when capture is disabled
+	validator = newTestValidator(t, schema)
+	result, _ = validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nreplicas: 0\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	output, _ = FormatResultsJSON([]ValidationResult{result})
+	if bytes.Contains(output, []byte("source_line")) {
+		t.Errorf("source_line should be omitted:\n%s", output)
+	}
+}
+
+// TestWriteResultsJSONL tests writing results as JSON Lines as they arrive
+func TestWriteResultsJSONL(t *testing.T) {
+	results := make(chan ValidationResult)
+	writer := &flushRecorder{flushed: make(chan string, 3)}
+
+	done := make(chan error)
+	go func() { done <- WriteResultsJSONL(writer, results) }()
+
+	inputs := []ValidationResult{
+		{Name: "a.yaml", Valid: true},
+		{Name: "b.json", Errors: []ValidationError{{Line: 2, Path: "port", Message: "conflicting values"}}},
+	}
+	var lines []string
+	for _, result := range inputs {
+		results <- result
+		// Each result is flushed before the next one is received
+		lines = append(lines, <-writer.flushed)
+	}
+	close(results)
+	if err := <-done; err != nil {
+		t.Fatalf("WriteResultsJSONL failed: %v", err)
+	}
+
+	want := `{"name":"a.yaml","valid":true,"errors":[]}` + "\n"
+	if lines[0] != want {
+		t.Errorf("line 1 = %q, want %q", lines[0], want)
+	}
+	var decoded ValidationResult
+	if err := json.Unmarshal([]byte(lines[1]), &decoded); err != nil {
+		t.Fatalf("line 2 is not JSON: %v", err)
+	}
+	if decoded.Name != "b.json" || len(decoded.Errors) != 1 || decoded.Errors[0].Path != "port" {
+		t.Errorf("line 2 decoded to %+v", decoded)
+	}
+}
+
+// flushRecorder is a writer that sends the data written since the last
+// flush on each Flush call
+type flushRecorder struct {
+	buffer  bytes.Buffer
+	flushed chan string
+}
+
+func (r *flushRecorder) Write(p []byte) (int, error) {
+	return r.buffer.Write(p)
+}
+
+func (r *flushRecorder) Flush() error {
+	r.flushed <- r.buffer.String()
+	r.buffer.Reset()
+	return nil
+}
+
+// TestFormatResultsCSV tests CSV output, including escaping and valid results
+func TestFormatResultsCSV(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "ok.yaml", Valid: true},
+		{Name: "bad, old.json", Valid: false, Errors: []ValidationError{
+			{Line: 5, Column: 11, Path: "replicas", Message: "invalid value 0 (out of bound >=1)"},
+			{Path: "name", Message: `value "My App" does not match pattern ^[a-z]+$, see "naming"`},
+			{Message: "failed to parse:\nunexpected EOF"},
+		}},
+	}
+
+	output, err := FormatResultsCSV(results)
+	if err != nil {
+		t.Fatalf("FormatResultsCSV failed: %v", err)
+	}
+
+	want := `name,valid,line,column,path,message
+ok.yaml,true,,,,
+"bad, old.json",false,5,11,replicas,invalid value 0 (out of bound >=1)
+"bad, old.json",false,,,name,"value ""My App"" does not match pattern ^[a-z]+$, see ""naming"""
+"bad, old.json",false,,,,"failed to parse:
+unexpected EOF"
+`
+	if string(output) != want {
+		t.Errorf("output =\n%s\nwant\n%s", output, want)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if got := records[3][5]; got != results[1].Errors[1].Message {
+		t.Errorf("message = %q, want %q", got, results[1].Errors[1].Message)
+	}
+
+	// Input-controlled text that a spreadsheet would evaluate is quoted
+	output, err = FormatResultsCSV([]ValidationResult{
+		{Name: "=HYPERLINK(\"http://x\")", Valid: false, Errors: []ValidationError{
+			{Path: "@sum", Message: "+1"},
+			{Path: "-", Message: "\tcmd"},
+			{Path: "a=b", Message: "\rx"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("FormatResultsCSV failed: %v", err)
+	}
+	records, err = csv.NewReader(bytes.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	wantCells := [][]string{
+		{`'=HYPERLINK("http://x")`, "'@sum", "'+1"},
+		{`'=HYPERLINK("http://x")`, "'-", "'\tcmd"},
+		{`'=HYPERLINK("http://x")`, "a=b", "'\rx"},
+	}
+	for i, want := range wantCells {
+		record := records[i+1]
+		if got := []string{record[0], record[4], record[5]}; !slices.Equal(got, want) {
+			t.Errorf("row %d = %q, want %q", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/build"
+	"cuelang.org/go/cue/literal"
+)
+
+// checkImports returns an error for the first import of the instance, or
+// of any package it imports, that is disallowed. An entry disallows the
+// package of that path and the packages below it (e.g., "tool" disallows
+// "tool/exec").
+func checkImports(instance *build.Instance, disallowed []string) error {
+	if len(disallowed) == 0 {
+		return nil
+	}
+	return checkInstanceImports(instance, disallowed, map[*build.Instance]bool{})
+}
+
+// checkInstanceImports checks the import declarations in the files of
+// instance, then the instances it imports, each once
+func checkInstanceImports(instance *build.Instance, disallowed []string, seen map[*build.Instance]bool) error {
+	if seen[instance] {
+		return nil
+	}
+	seen[instance] = true
+
+	for _, file := range instance.Files {
+		for _, spec := range file.Imports {
+			importPath, err := literal.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			// Drop a package qualifier (e.g., "example.com/schemas:v1")
+			importPath, _, _ = strings.Cut(importPath, ":")
+			if isDisallowedImport(importPath, disallowed) {
+				return fmt.Errorf("%s: import of %q is not allowed", spec.Pos(), importPath)
+			}
+		}
+	}
+
+	for _, imported := range instance.Imports {
+		if err := checkInstanceImports(imported, disallowed, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDisallowedImport reports whether importPath is or is below an entry of disallowed
+func isDisallowedImport(importPath string, disallowed []string) bool {
+	for _, entry := range disallowed {
+		entry = strings.TrimSuffix(entry, "/")
+		if importPath == entry || strings.HasPrefix(importPath, entry+"/") {
+			return true
+		}
+	}
+	return false
+}
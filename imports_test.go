@@ -0,0 +1,58 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDisallowedImports tests rejecting schemas that import disallowed packages
+func TestDisallowedImports(t *testing.T) {
+	moduleDir := t.TempDir()
+	files := map[string]string{
+		"cue.mod/module.cue": "module: \"example.com/schemas\"\nlanguage: version: \"v0.9.0\"\n",
+		"common/common.cue":  "package common\n\nimport \"strings\"\n\n#Name: string & strings.MinRunes(1)\n",
+		"direct.cue":         "package schemas\n\nimport \"tool/os\"\n\n#Config: {env: os.Getenv}\n",
+		"indirect.cue":       "package schemas\n\nimport \"example.com/schemas/common\"\n\n#Config: {name: common.#Name}\n",
+		"plain.cue":          "package schemas\n\n#Config: {name: string}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(moduleDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schemaFile string
+		disallowed []string
+		wantErr    string
+	}{
+		{name: "exact package", schemaFile: "direct.cue", disallowed: []string{"tool/os"}, wantErr: `direct.cue:3:8: import of "tool/os" is not allowed`},
+		{name: "parent path", schemaFile: "direct.cue", disallowed: []string{"tool"}, wantErr: `import of "tool/os" is not allowed`},
+		{name: "transitive import", schemaFile: "indirect.cue", disallowed: []string{"strings"}, wantErr: `common.cue:3:8: import of "strings" is not allowed`},
+		{name: "module package", schemaFile: "indirect.cue", disallowed: []string{"example.com/schemas/common"}, wantErr: `import of "example.com/schemas/common" is not allowed`},
+		{name: "other packages allowed", schemaFile: "indirect.cue", disallowed: []string{"tool", "str"}},
+		{name: "no imports", schemaFile: "plain.cue", disallowed: []string{"tool"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidatorWithOptions(filepath.Join(moduleDir, tt.schemaFile), "#Config", ValidationOptions{DisallowedImports: tt.disallowed})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
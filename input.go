@@ -1,13 +1,169 @@
 package cuebridge
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
-// readInput reads data from the specified input source
+// Validate checks that the field required by the input's source type is
+// set (FilePath for SourceFile, Reader for SourceReader, Data for
+// SourceBytes), without reading anything. Every Validator method that
+// takes an input calls it before any I/O.
+func (input ValidationInput) Validate() error {
+	switch input.SourceType {
+	case SourceFile:
+		if input.FilePath == "" {
+			return fmt.Errorf("invalid input %q: SourceFile requires FilePath", input.Name)
+		}
+	case SourceReader:
+		if input.Reader == nil {
+			return fmt.Errorf("invalid input %q: SourceReader requires Reader", input.Name)
+		}
+	case SourceBytes:
+		if input.Data == nil {
+			return fmt.Errorf("invalid input %q: SourceBytes requires Data", input.Name)
+		}
+	default:
+		return fmt.Errorf("invalid input %q: unknown source type: %d", input.Name, input.SourceType)
+	}
+	if input.Encoding != EncodingNone && input.Encoding != EncodingBase64 {
+		return fmt.Errorf("invalid input %q: unknown encoding: %d", input.Name, input.Encoding)
+	}
+	return nil
+}
+
+// readInput reads data from the specified input source as UTF-8 text
 func readInput(input ValidationInput) ([]byte, error) {
+	data, err := readRawInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = decodeEncoding(data, input); err != nil {
+		return nil, err
+	}
+	return decodeText(data)
+}
+
+// decodeEncoding removes the input's transfer encoding from data
+func decodeEncoding(data []byte, input ValidationInput) ([]byte, error) {
+	switch input.Encoding {
+	case EncodingNone:
+		return data, nil
+	case EncodingBase64:
+		// Accept any alphabet and padding by normalizing to unpadded text
+		text := strings.Join(strings.Fields(string(data)), "")
+		text = strings.TrimRight(text, "=")
+		encoding := base64.RawStdEncoding
+		if strings.ContainsAny(text, "-_") {
+			encoding = base64.RawURLEncoding
+		}
+		decoded, err := encoding.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("decoding base64 input %s: %w", input.Name, err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding: %d", input.Encoding)
+	}
+}
+
+// readInput reads data from the input source, refusing invalid inputs
+// (see ValidationInput.Validate) and, when the validator is sandboxed, file
+// sources. Line endings are normalized unless the validator keeps them.
+func (v *Validator) readInput(input ValidationInput) ([]byte, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input.SourceType == SourceFile {
+		if err := v.checkFileAccess(input.FilePath); err != nil {
+			return nil, err
+		}
+	}
+	data, err := readInput(input)
+	if err != nil || v.options.KeepLineEndings {
+		return data, err
+	}
+	return normalizeLineEndings(data), nil
+}
+
+// normalizeLineEndings converts CRLF and lone CR line endings to LF
+func normalizeLineEndings(data []byte) []byte {
+	if !bytes.ContainsRune(data, '\r') {
+		return data
+	}
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+}
+
+// checkFileAccess returns an error if the validator may not read path
+func (v *Validator) checkFileAccess(path string) error {
+	if v.options.Sandbox {
+		return fmt.Errorf("cannot read %s: file access is disabled (sandbox mode)", path)
+	}
+	return nil
+}
+
+// openInput opens the input source for streaming reads, refusing invalid
+// inputs and decoding text like readInput. The caller must close the
+// returned reader.
+func (v *Validator) openInput(input ValidationInput) (io.ReadCloser, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input.Encoding != EncodingNone {
+		// Decode up front; encoded inputs are small enough to hold in memory
+		data, err := v.readInput(input)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	raw, err := v.openRawInput(input)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{decodeTextReader(raw), raw}, nil
+}
+
+// openRawInput opens the input source without decoding it
+func (v *Validator) openRawInput(input ValidationInput) (io.ReadCloser, error) {
+	switch input.SourceType {
+	case SourceFile:
+		if err := v.checkFileAccess(input.FilePath); err != nil {
+			return nil, err
+		}
+		file, err := os.Open(input.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading file %s: %w", input.FilePath, err)
+		}
+		return file, nil
+	case SourceReader:
+		if input.Reader == nil {
+			return nil, fmt.Errorf("reader is nil")
+		}
+		return io.NopCloser(input.Reader), nil
+	case SourceBytes:
+		return io.NopCloser(bytes.NewReader(input.Data)), nil
+	default:
+		return nil, fmt.Errorf("unknown source type: %d", input.SourceType)
+	}
+}
+
+// readRawInput reads data from the specified input source
+func readRawInput(input ValidationInput) ([]byte, error) {
 	switch input.SourceType {
 	case SourceFile:
 		return readFromFile(input.FilePath)
@@ -20,6 +176,119 @@ func readInput(input ValidationInput) ([]byte, error) {
 	}
 }
 
+// decodeText strips a UTF-8 byte order mark and transcodes UTF-16 input
+// (detected by its byte order mark) to UTF-8
+func decodeText(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return data[3:], nil
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], binary.BigEndian)
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], binary.LittleEndian)
+	default:
+		return data, nil
+	}
+}
+
+// decodeTextReader is the streaming counterpart of decodeText: it strips a
+// UTF-8 byte order mark and transcodes UTF-16 input as it is read
+func decodeTextReader(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	bom, _ := buffered.Peek(3)
+	switch {
+	case bytes.HasPrefix(bom, []byte{0xEF, 0xBB, 0xBF}):
+		buffered.Discard(3)
+		return buffered
+	case bytes.HasPrefix(bom, []byte{0xFE, 0xFF}):
+		buffered.Discard(2)
+		return &utf16Reader{r: buffered, order: binary.BigEndian}
+	case bytes.HasPrefix(bom, []byte{0xFF, 0xFE}):
+		buffered.Discard(2)
+		return &utf16Reader{r: buffered, order: binary.LittleEndian}
+	default:
+		return buffered
+	}
+}
+
+// utf16Reader transcodes UTF-16 without byte order mark to UTF-8,
+// replacing unpaired surrogates like utf16.Decode
+type utf16Reader struct {
+	r       io.Reader
+	order   binary.ByteOrder
+	pending []byte
+	err     error
+	// carried is a unit read ahead that did not complete a surrogate pair
+	carried    uint16
+	hasCarried bool
+}
+
+// Read implements io.Reader
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.pending) == 0 {
+		if u.err != nil {
+			return 0, u.err
+		}
+		u.fill()
+	}
+	n := copy(p, u.pending)
+	u.pending = u.pending[n:]
+	return n, nil
+}
+
+// fill decodes a batch of units into pending, recording any read error
+func (u *utf16Reader) fill() {
+	for range 1024 {
+		unit, err := u.readUnit()
+		if err != nil {
+			u.err = err
+			return
+		}
+		r := rune(unit)
+		if utf16.IsSurrogate(r) {
+			next, err := u.readUnit()
+			if err != nil {
+				u.pending = utf8.AppendRune(u.pending, unicode.ReplacementChar)
+				u.err = err
+				return
+			}
+			if r = utf16.DecodeRune(r, rune(next)); r == unicode.ReplacementChar {
+				u.carried, u.hasCarried = next, true
+			}
+		}
+		u.pending = utf8.AppendRune(u.pending, r)
+	}
+}
+
+// readUnit returns the next UTF-16 code unit
+func (u *utf16Reader) readUnit() (uint16, error) {
+	if u.hasCarried {
+		u.hasCarried = false
+		return u.carried, nil
+	}
+	var unit [2]byte
+	if _, err := io.ReadFull(u.r, unit[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = fmt.Errorf("invalid UTF-16 input: odd number of bytes")
+		}
+		return 0, err
+	}
+	return u.order.Uint16(unit[:]), nil
+}
+
+// decodeUTF16 transcodes UTF-16 data without byte order mark to UTF-8
+func decodeUTF16(data []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("invalid UTF-16 input: odd number of bytes")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
 // readFromFile reads data from a file
 func readFromFile(filePath string) ([]byte, error) {
 	data, err := os.ReadFile(filePath)
@@ -0,0 +1,299 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+)
+
+// TestByteOrderMark tests inputs with byte order marks
+func TestByteOrderMark(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string, replicas: int}`)
+	yamlContent := "name: test\nreplicas: 2\n"
+	jsonContent := `{"name": "test", "replicas": 2}`
+
+	tests := []struct {
+		name   string
+		data   []byte
+		format DataFormat
+	}{
+		{name: "yaml utf-8 bom", data: append([]byte{0xEF, 0xBB, 0xBF}, yamlContent...), format: FormatYAML},
+		{name: "json utf-8 bom", data: append([]byte{0xEF, 0xBB, 0xBF}, jsonContent...), format: FormatJSON},
+		{name: "json utf-16le bom", data: encodeUTF16LE(jsonContent), format: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config")
+			if err := os.WriteFile(configPath, tt.data, 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceFile,
+				FilePath:   configPath,
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if !result.Valid {
+				t.Errorf("expected valid result, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+// encodeUTF16LE encodes s as UTF-16LE with a byte order mark
+func encodeUTF16LE(s string) []byte {
+	data := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune(s)) {
+		data = binary.LittleEndian.AppendUint16(data, unit)
+	}
+	return data
+}
+
+// TestSandbox tests refusing file access in sandbox mode
+func TestSandbox(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string}`, ValidationOptions{Sandbox: true})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("name: test\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, err := validator.ValidateFile(path, FormatYAML); err == nil {
+		t.Error("ValidateFile: expected error in sandbox mode")
+	}
+	if _, err := validator.ValidateDir(dir); err == nil {
+		t.Error("ValidateDir: expected error in sandbox mode")
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: test\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate with bytes failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("expected valid result, got %v", result.Errors)
+	}
+}
+
+// TestValidationInputValidate tests checking inputs for their required fields
+func TestValidationInputValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ValidationInput
+		wantErr string
+	}{
+		{name: "file", input: ValidationInput{SourceType: SourceFile, FilePath: "config.yaml"}},
+		{name: "reader", input: ValidationInput{SourceType: SourceReader, Reader: strings.NewReader("")}},
+		{name: "bytes", input: ValidationInput{SourceType: SourceBytes, Data: []byte{}}},
+		{name: "file without path", input: ValidationInput{SourceType: SourceFile, Name: "a"}, wantErr: `invalid input "a": SourceFile requires FilePath`},
+		{name: "reader without reader", input: ValidationInput{SourceType: SourceReader, Name: "b"}, wantErr: `invalid input "b": SourceReader requires Reader`},
+		{name: "bytes without data", input: ValidationInput{SourceType: SourceBytes, Name: "c"}, wantErr: `invalid input "c": SourceBytes requires Data`},
+		{name: "unknown source", input: ValidationInput{SourceType: InputSourceType(99), Name: "d"}, wantErr: `invalid input "d": unknown source type: 99`},
+	}
+
+	validator := newTestValidator(t, `#Config: {name: string}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := validator.Validate(tt.input); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validator.Validate error = %v, want %q", err, tt.wantErr)
+			}
+
+			// Every method taking an input refuses it before reading
+			methods := map[string]func() error{
+				"Canonicalize":   func() error { _, _, err := validator.Canonicalize(tt.input); return err },
+				"ValidateStream": func() error { _, err := validator.ValidateStream(tt.input); return err },
+				"ValidatePatch":  func() error { _, err := validator.ValidatePatch(tt.input); return err },
+				"ValidateLayered": func() error {
+					_, _, err := validator.ValidateLayered(tt.input, ValidationInput{SourceType: SourceBytes, Data: []byte("{}")})
+					return err
+				},
+				"ValidateJSONStream": func() error {
+					for _, err := range validator.ValidateJSONStream(tt.input) {
+						return err
+					}
+					return nil
+				},
+			}
+			for name, method := range methods {
+				if err := method(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("%s error = %v, want it to contain %q", name, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+// TestBase64Encoding tests decoding base64 inputs before parsing
+func TestBase64Encoding(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string, replicas: int}`)
+	valid := `{"name": "test", "replicas": 2}`
+	// Contains "?>" so that the standard and URL-safe encodings differ
+	urlSafe := "name: \"a?>\"\nreplicas: 1\n"
+
+	tests := []struct {
+		name      string
+		data      string
+		format    DataFormat
+		wantValid bool
+		wantErr   string
+	}{
+		{name: "standard", data: base64.StdEncoding.EncodeToString([]byte(valid)), format: FormatJSON, wantValid: true},
+		{name: "standard unpadded", data: base64.RawStdEncoding.EncodeToString([]byte(valid)), format: FormatJSON, wantValid: true},
+		{name: "url-safe", data: base64.URLEncoding.EncodeToString([]byte(urlSafe)), format: FormatYAML, wantValid: true},
+		{name: "standard with special characters", data: base64.StdEncoding.EncodeToString([]byte(urlSafe)), format: FormatYAML, wantValid: true},
+		{name: "wrapped lines", data: wrapLines(base64.StdEncoding.EncodeToString([]byte(valid)), 8), format: FormatAuto, wantValid: true},
+		{name: "invalid data", data: base64.StdEncoding.EncodeToString([]byte(`{"name": 1, "replicas": 2}`)), format: FormatJSON},
+		{name: "malformed base64", data: "not*base64", format: FormatJSON, wantErr: "decoding base64 input secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceReader,
+				Reader:     strings.NewReader(tt.data),
+				Format:     tt.format,
+				Encoding:   EncodingBase64,
+				Name:       "secret",
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+		})
+	}
+}
+
+// wrapLines breaks s into lines of at most width characters
+func wrapLines(s string, width int) string {
+	var lines []string
+	for len(s) > width {
+		lines = append(lines, s[:width])
+		s = s[width:]
+	}
+	return strings.Join(append(lines, s), "\n") + "\n"
+}
+
+// TestLineEndings tests normalizing CRLF and CR line endings
+func TestLineEndings(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string, description: string, port: int}`, ValidationOptions{
+		PreferInputPositions: true,
+		CaptureSourceLines:   true,
+	})
+
+	tests := []struct {
+		name   string
+		data   string
+		format DataFormat
+	}{
+		{name: "yaml crlf", data: "name: app\r\ndescription: |\r\n  first\r\n  second\r\nport: http\r\n", format: FormatYAML},
+		{name: "yaml cr", data: "name: app\rdescription: |\r  first\r  second\rport: http\r", format: FormatYAML},
+		{name: "json crlf", data: "{\r\n  \"name\": \"app\",\r\n  \"description\": \"first\\nsecond\\n\",\r\n\r\n  \"port\": \"http\"\r\n}\r\n", format: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config")
+			if err := os.WriteFile(configPath, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceFile,
+				FilePath:   configPath,
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("expected 1 error, got %v", result.Errors)
+			}
+			e := result.Errors[0]
+			if e.Path != "port" || e.Line != 5 || strings.ContainsRune(e.SourceLine, '\r') {
+				t.Errorf("got error at %s line %d (%q), want port at line 5", e.Path, e.Line, e.SourceLine)
+			}
+		})
+	}
+}
+
+// TestDecodeTextReader tests that streamed text decodes like decodeText
+func TestDecodeTextReader(t *testing.T) {
+	be := func(units ...uint16) []byte {
+		data := []byte{0xFE, 0xFF}
+		for _, unit := range units {
+			data = binary.BigEndian.AppendUint16(data, unit)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "plain", data: []byte(`{"a": 1}`)},
+		{name: "utf-8 bom", data: []byte("\uFEFF{\"a\": 1}")},
+		{name: "utf-16le pairs", data: encodeUTF16LE(`{"a": "😀é"}`)},
+		{name: "unpaired high surrogate", data: be('a', 0xD83D, 'b', 0xD83D)},
+		{name: "unpaired low surrogate", data: be(0xDE00, 0xD83D, 0xDE00)},
+		{name: "odd length", data: append(be('a'), 'b'), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(iotest.OneByteReader(decodeTextReader(bytes.NewReader(tt.data))))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reading failed: %v", err)
+			}
+			want, err := decodeText(tt.data)
+			if err != nil {
+				t.Fatalf("decodeText failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package cuebridge
+
+import (
+	"bytes"
+)
+
+// stripJSONC converts JSON with comments (JSONC) to plain JSON by blanking
+// out line comments (// ...), block comments (/* ... */), and trailing
+// commas before a closing } or ]. Stripped characters are replaced with
+// spaces and newlines are kept, so line and column positions in the
+// result match the input. An unterminated block comment is left in place
+// for the JSON parser to report.
+func stripJSONC(data []byte) []byte {
+	out := append([]byte(nil), data...)
+
+	// Blank out comments, skipping string literals
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			i = skipJSONString(out, i)
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			for end += i + 4; i < end; i++ {
+				if out[i] != '\n' && out[i] != '\r' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+
+	// Blank out commas followed only by whitespace and a closing bracket
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			i = skipJSONString(out, i)
+		case ',':
+			next := i + 1
+			for next < len(out) && isJSONSpace(out[next]) {
+				next++
+			}
+			if next < len(out) && (out[next] == '}' || out[next] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+
+	return out
+}
+
+// skipJSONString returns the index of the quote closing the string that
+// starts at start, or the last index if the string is not closed
+func skipJSONString(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(data) - 1
+}
+
+// isJSONSpace reports whether b is JSON whitespace
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,62 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/encoding/jsonschema"
+)
+
+// newValidatorFromSchemaFile creates a new Validator from a CUE or JSON Schema file
+func newValidatorFromSchemaFile(schemaPath string, definitionName string) (*Validator, error) {
+	format, ok := formatFromExtension(schemaPath)
+	if !ok {
+		return newValidator(schemaPath, definitionName, ValidationOptions{})
+	}
+
+	schema, err := compileJSONSchemaFile(cuecontext.New(), schemaPath, format, definitionName)
+	if err != nil {
+		return nil, err
+	}
+	return newValidatorFromValue(schema, schemaPath, definitionName, ValidationOptions{})
+}
+
+// compileJSONSchemaFile converts a JSON Schema file in JSON or YAML to CUE.
+// Unless the schema defines definitionName, the root schema is placed there.
+func compileJSONSchemaFile(ctx *cue.Context, schemaPath string, format DataFormat, definitionName string) (cue.Value, error) {
+	data, err := readFromFile(schemaPath)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("reading schema file: %w", err)
+	}
+	if data, err = decodeText(data); err != nil {
+		return cue.Value{}, fmt.Errorf("reading schema file: %w", err)
+	}
+
+	document, err := parseData(ctx, data, format, schemaPath, parseOptions{})
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("parsing schema: %w", err)
+	}
+	if document.Err() != nil {
+		return cue.Value{}, fmt.Errorf("parsing schema: %w", document.Err())
+	}
+
+	file, err := jsonschema.Extract(document, &jsonschema.Config{})
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("converting JSON Schema: %w", err)
+	}
+
+	schema := ctx.BuildFile(file)
+	if schema.Err() != nil {
+		return cue.Value{}, fmt.Errorf("compiling schema: %w", schema.Err())
+	}
+
+	path := cue.ParsePath(definitionName)
+	if path.Err() != nil {
+		return cue.Value{}, fmt.Errorf("invalid definition name %s: %w", definitionName, path.Err())
+	}
+	if !schema.LookupPath(path).Exists() {
+		schema = schema.FillPath(path, schema)
+	}
+	return schema, nil
+}
@@ -0,0 +1,99 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewValidatorFromSchemaFile tests loading schemas in several formats
+func TestNewValidatorFromSchemaFile(t *testing.T) {
+	schemas := map[string]string{
+		"schema.cue": `#Config: {
+	name!: string
+	port?: int & >=1
+}`,
+		"schema.json": `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "port": {"$ref": "#/$defs/port"}
+  },
+  "required": ["name"],
+  "additionalProperties": false,
+  "$defs": {"port": {"type": "integer", "minimum": 1}}
+}`,
+		"schema.yaml": `type: object
+properties:
+  name: {type: string}
+  port: {type: integer, minimum: 1}
+required: [name]
+additionalProperties: false
+`,
+	}
+
+	inputs := []struct {
+		data      string
+		wantValid bool
+	}{
+		{data: `{"name": "app", "port": 8080}`, wantValid: true},
+		{data: `{"name": "app", "port": 0}`, wantValid: false},
+		{data: `{"port": 8080}`, wantValid: false},
+		{data: `{"name": "app", "extra": true}`, wantValid: false},
+	}
+
+	for file, content := range schemas {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			validator, err := NewValidatorFromSchemaFile(path, "#Config")
+			if err != nil {
+				t.Fatalf("NewValidatorFromSchemaFile failed: %v", err)
+			}
+
+			for _, in := range inputs {
+				result, err := validator.Validate(ValidationInput{
+					SourceType: SourceBytes,
+					Data:       []byte(in.data),
+					Format:     FormatJSON,
+					Name:       "config.json",
+				})
+				if err != nil {
+					t.Fatalf("Validate failed: %v", err)
+				}
+				if result.Valid != in.wantValid {
+					t.Errorf("%s: Valid = %v, want %v (errors: %v)", in.data, result.Valid, in.wantValid, result.Errors)
+				}
+			}
+		})
+	}
+}
+
+// TestNewValidatorFromSchemaFileDefinition tests selecting a JSON Schema definition
+func TestNewValidatorFromSchemaFileDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	content := `{"$defs": {"port": {"type": "integer", "minimum": 1}}}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	validator, err := NewValidatorFromSchemaFile(path, "#port")
+	if err != nil {
+		t.Fatalf("NewValidatorFromSchemaFile failed: %v", err)
+	}
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`0`),
+		Format:     FormatJSON,
+		Name:       "port.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("expected port 0 to be invalid")
+	}
+}
@@ -0,0 +1,98 @@
+package cuebridge
+
+import (
+	"strings"
+	"unicode"
+
+	"cuelang.org/go/cue/ast"
+)
+
+// KeyCase selects how input object keys are renamed before validation
+type KeyCase int
+
+const (
+	// KeyCaseNone keeps keys as written
+	KeyCaseNone KeyCase = iota
+	// KeyCaseSnakeToCamel renames snake_case keys to camelCase
+	// (e.g., "max_retries" to "maxRetries")
+	KeyCaseSnakeToCamel
+	// KeyCaseCamelToSnake renames camelCase keys to snake_case
+	// (e.g., "maxRetries" to "max_retries", "httpPort" or "HTTPPort" to "http_port")
+	KeyCaseCamelToSnake
+)
+
+// normalizeKeyCase renames the object keys of node in place, recursively,
+// keeping their positions so that errors still point at the input
+func normalizeKeyCase(node ast.Node, keyCase KeyCase) {
+	if keyCase == KeyCaseNone {
+		return
+	}
+	ast.Walk(node, func(n ast.Node) bool {
+		field, ok := n.(*ast.Field)
+		if !ok {
+			return true
+		}
+		name, _, err := ast.LabelName(field.Label)
+		if err != nil {
+			return true
+		}
+		if renamed := convertKeyCase(name, keyCase); renamed != name {
+			label := ast.NewString(renamed)
+			ast.SetPos(label, field.Label.Pos())
+			field.Label = label
+		}
+		return true
+	}, nil)
+}
+
+// convertKeyCase converts a single key; keys starting with "_" are kept
+func convertKeyCase(key string, keyCase KeyCase) string {
+	if strings.HasPrefix(key, "_") {
+		return key
+	}
+	switch keyCase {
+	case KeyCaseSnakeToCamel:
+		return snakeToCamel(key)
+	case KeyCaseCamelToSnake:
+		return camelToSnake(key)
+	default:
+		return key
+	}
+}
+
+// snakeToCamel converts "max_retries" to "maxRetries"
+func snakeToCamel(key string) string {
+	if !strings.Contains(key, "_") {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	var camel strings.Builder
+	camel.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		runes := []rune(part)
+		if len(runes) == 0 {
+			continue
+		}
+		runes[0] = unicode.ToUpper(runes[0])
+		camel.WriteString(string(runes))
+	}
+	return camel.String()
+}
+
+// camelToSnake converts "maxRetries" to "max_retries". A run of capitals is
+// one word, so "HTTPPort" becomes "http_port".
+func camelToSnake(key string) string {
+	runes := []rune(key)
+	var snake strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				snake.WriteRune('_')
+			}
+		}
+		snake.WriteRune(unicode.ToLower(r))
+	}
+	return snake.String()
+}
@@ -0,0 +1,109 @@
+package cuebridge
+
+import (
+	"testing"
+)
+
+// TestConvertKeyCase tests renaming single keys
+func TestConvertKeyCase(t *testing.T) {
+	tests := []struct {
+		key     string
+		keyCase KeyCase
+		want    string
+	}{
+		{"max_retries", KeyCaseSnakeToCamel, "maxRetries"},
+		{"http_port_2", KeyCaseSnakeToCamel, "httpPort2"},
+		{"name", KeyCaseSnakeToCamel, "name"},
+		{"_internal_id", KeyCaseSnakeToCamel, "_internal_id"},
+		{"maxRetries", KeyCaseCamelToSnake, "max_retries"},
+		{"HTTPPort", KeyCaseCamelToSnake, "http_port"},
+		{"port2Name", KeyCaseCamelToSnake, "port2_name"},
+		{"already_snake", KeyCaseCamelToSnake, "already_snake"},
+		{"maxRetries", KeyCaseNone, "maxRetries"},
+	}
+
+	for _, tt := range tests {
+		if got := convertKeyCase(tt.key, tt.keyCase); got != tt.want {
+			t.Errorf("convertKeyCase(%q, %d) = %q, want %q", tt.key, tt.keyCase, got, tt.want)
+		}
+	}
+}
+
+// TestKeyCaseNormalization tests validating inputs with renamed keys
+func TestKeyCaseNormalization(t *testing.T) {
+	camel := newTestValidatorWithOptions(t, `#Config: {
+	serviceName: string
+	maxRetries:  int & >0
+	endpoints: [...{hostName: string}]
+}`, ValidationOptions{KeyCaseNormalization: KeyCaseSnakeToCamel, PreferInputPositions: true})
+
+	snake := newTestValidatorWithOptions(t, `#Config: {
+	service_name: string
+	max_retries:  int & >0
+	endpoints: [...{host_name: string}]
+}`, ValidationOptions{KeyCaseNormalization: KeyCaseCamelToSnake, PreferInputPositions: true})
+
+	tests := []struct {
+		name      string
+		validator *Validator
+		data      string
+		format    DataFormat
+		wantPaths []string
+		wantLines []int
+	}{
+		{
+			name:      "snake_case YAML against camelCase schema",
+			validator: camel,
+			data:      "service_name: api\nmax_retries: 3\nendpoints:\n  - host_name: a.example.com\n",
+			format:    FormatYAML,
+		},
+		{
+			name:      "camelCase JSON against camelCase schema",
+			validator: camel,
+			data:      `{"serviceName": "api", "maxRetries": 3, "endpoints": []}`,
+			format:    FormatJSON,
+		},
+		{
+			name:      "errors use renamed keys and input lines",
+			validator: camel,
+			data:      "service_name: api\nmax_retries: 0\nendpoints: []\n",
+			format:    FormatYAML,
+			wantPaths: []string{"maxRetries"},
+			wantLines: []int{2},
+		},
+		{
+			name:      "camelCase JSON against snake_case schema",
+			validator: snake,
+			data:      `{"serviceName": "api", "maxRetries": 3, "endpoints": [{"hostName": "a.example.com"}]}`,
+			format:    FormatJSON,
+		},
+		{
+			name:      "camelCase TOML against snake_case schema",
+			validator: snake,
+			data:      "serviceName = \"api\"\nmaxRetries = 3\n\n[[endpoints]]\nhostName = \"a.example.com\"\n",
+			format:    FormatTOML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != (len(tt.wantPaths) == 0) {
+				t.Fatalf("Valid = %v, errors: %v", result.Valid, result.Errors)
+			}
+			for i, e := range result.Errors {
+				if i >= len(tt.wantPaths) || e.Path != tt.wantPaths[i] || e.Line != tt.wantLines[i] {
+					t.Errorf("error %d = %s at line %d, want paths %v at lines %v", i, e.Path, e.Line, tt.wantPaths, tt.wantLines)
+				}
+			}
+		})
+	}
+}
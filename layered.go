@@ -0,0 +1,138 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+)
+
+// ValidateLayered merges an override input onto a base input and validates
+// the merged value against the schema.
+//
+// Precedence rules:
+//   - Fields present only in base or only in override are kept as-is
+//   - Fields present in both are merged recursively when both are structs
+//   - Otherwise the override value replaces the base value (lists are
+//     replaced wholesale, not appended or merged element-wise)
+//
+// A field that is a struct on one side and a non-struct on the other is a
+// conflict and is reported as a validation error.
+//
+// The merged value is checked like a single input named after both:
+// base.Definition, base.InputPath, and the discriminator field select the
+// definition. The override must leave Definition and InputPath empty or
+// equal to the base's; ValidateLayered returns an error otherwise.
+//
+// On success, the merged value is returned encoded in the base input's
+// (resolved) format.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateLayered(base, override ValidationInput) (ValidationResult, []byte, error) {
+	name := base.Name
+	if override.Name != "" {
+		name = fmt.Sprintf("%s+%s", base.Name, override.Name)
+	}
+
+	if (override.Definition != "" && override.Definition != base.Definition) ||
+		(override.InputPath != "" && override.InputPath != base.InputPath) {
+		return ValidationResult{}, nil, fmt.Errorf("override: Definition and InputPath are taken from base")
+	}
+
+	baseInput, failed, err := v.parseInput(base)
+	if err != nil {
+		return ValidationResult{}, nil, fmt.Errorf("base: %w", err)
+	}
+	if failed != nil {
+		return *failed, nil, nil
+	}
+
+	overrideInput, failed, err := v.parseInput(override)
+	if err != nil {
+		return ValidationResult{}, nil, fmt.Errorf("override: %w", err)
+	}
+	if failed != nil {
+		return *failed, nil, nil
+	}
+
+	var conflicts []ValidationError
+	merged := mergeValues(v.ctx, baseInput.value, overrideInput.value, nil, &conflicts)
+	if len(conflicts) > 0 {
+		return v.withProvenance(ValidationResult{Name: name, Valid: false, Errors: conflicts}), nil, nil
+	}
+	// Each side is within the limits, but together they may not be
+	if err := newInputLimits(v.options).check(merged); err != nil {
+		return v.withProvenance(createErrorResult(name, err.Error())), nil, nil
+	}
+
+	// The merged value selects its definition like a single input would
+	mergedInput := parsedInput{
+		value:  merged,
+		source: &sourceContext{name: name, value: merged},
+	}
+	definitionInput := base
+	definitionInput.Name = name
+	definitionName, configDef, failed, err := v.inputDefinition(definitionInput, &mergedInput)
+	if err != nil {
+		return ValidationResult{}, nil, err
+	}
+	if failed != nil {
+		return *failed, nil, nil
+	}
+
+	result := v.checkInput(definitionName, configDef, mergedInput)
+	if !result.Valid {
+		return result, nil, nil
+	}
+
+	data, err := exportValue(merged, baseInput.format, v.options.Export, baseInput.source.data)
+	if err != nil {
+		return ValidationResult{}, nil, err
+	}
+
+	return result, data, nil
+}
+
+// mergeValues merges override onto base following the ValidateLayered precedence rules
+func mergeValues(ctx *cue.Context, base, override cue.Value, path []string, conflicts *[]ValidationError) cue.Value {
+	baseIsStruct := base.IncompleteKind() == cue.StructKind
+	overrideIsStruct := override.IncompleteKind() == cue.StructKind
+
+	if baseIsStruct != overrideIsStruct {
+		*conflicts = append(*conflicts, ValidationError{
+			Path:    formatPath(path),
+			Pointer: jsonPointer(path),
+			Message: fmt.Sprintf("conflicting values: base is %s, override is %s",
+				base.IncompleteKind(), override.IncompleteKind()),
+		})
+		return override
+	}
+	if !baseIsStruct {
+		return override
+	}
+
+	merged := ctx.CompileString("{}")
+
+	// Base fields first, merged with their overrides where present
+	baseFields, _ := base.Fields()
+	for baseFields.Next() {
+		sel := baseFields.Selector()
+		value := baseFields.Value()
+
+		overrideField := override.LookupPath(cue.MakePath(sel))
+		if overrideField.Exists() {
+			value = mergeValues(ctx, value, overrideField, append(path, sel.String()), conflicts)
+		}
+		merged = merged.FillPath(cue.MakePath(sel), value)
+	}
+
+	// Then fields only present in override
+	overrideFields, _ := override.Fields()
+	for overrideFields.Next() {
+		sel := overrideFields.Selector()
+		if base.LookupPath(cue.MakePath(sel)).Exists() {
+			continue
+		}
+		merged = merged.FillPath(cue.MakePath(sel), overrideFields.Value())
+	}
+
+	return merged
+}
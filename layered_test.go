@@ -0,0 +1,128 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidateLayered tests merging an override onto a base config
+func TestValidateLayered(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name: string
+	replicas: int & >=1
+	labels: [string]: string
+}`)
+
+	tests := []struct {
+		name      string
+		base      string
+		override  string
+		wantValid bool
+		wantData  []string
+	}{
+		{
+			name:      "override replaces scalar and merges struct",
+			base:      "name: app\nreplicas: 1\nlabels:\n  tier: web\n",
+			override:  "replicas: 3\nlabels:\n  env: prod\n",
+			wantValid: true,
+			wantData:  []string{"replicas: 3", "tier: web", "env: prod"},
+		},
+		{
+			name:      "merged value fails schema",
+			base:      "name: app\nreplicas: 1\n",
+			override:  "replicas: 0\n",
+			wantValid: false,
+		},
+		{
+			name:      "struct and scalar conflict",
+			base:      "name: app\nreplicas: 1\nlabels:\n  tier: web\n",
+			override:  "labels: none\n",
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, data, err := validator.ValidateLayered(
+				ValidationInput{SourceType: SourceBytes, Data: []byte(tt.base), Format: FormatYAML, Name: "base.yaml"},
+				ValidationInput{SourceType: SourceBytes, Data: []byte(tt.override), Format: FormatYAML, Name: "prod.yaml"},
+			)
+			if err != nil {
+				t.Fatalf("ValidateLayered failed: %v", err)
+			}
+
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			for _, want := range tt.wantData {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("merged data missing %q:\n%s", want, data)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateLayeredDefinition tests checking the merged value against the
+// definition and input path of the base input
+func TestValidateLayeredDefinition(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}
+#Worker: {queue: string, concurrency: int & >=1}`)
+
+	input := func(data, definition, inputPath string) ValidationInput {
+		return ValidationInput{SourceType: SourceBytes, Data: []byte(data), Format: FormatYAML, Name: "layer.yaml", Definition: definition, InputPath: inputPath}
+	}
+
+	tests := []struct {
+		name      string
+		base      ValidationInput
+		override  ValidationInput
+		wantValid bool
+		wantErr   string
+	}{
+		{
+			name:      "definition",
+			base:      input("queue: jobs\nconcurrency: 1\n", "#Worker", ""),
+			override:  input("concurrency: 4\n", "", ""),
+			wantValid: true,
+		},
+		{
+			name:     "definition rejects",
+			base:     input("queue: jobs\nconcurrency: 1\n", "#Worker", ""),
+			override: input("concurrency: 0\n", "#Worker", ""),
+		},
+		{
+			name:      "input path",
+			base:      input("worker:\n  queue: jobs\n  concurrency: 1\nother: x\n", "#Worker", "worker"),
+			override:  input("worker:\n  concurrency: 2\n", "", "worker"),
+			wantValid: true,
+		},
+		{
+			name:     "different override definition",
+			base:     input("queue: jobs\nconcurrency: 1\n", "#Worker", ""),
+			override: input("name: x\n", "#Config", ""),
+			wantErr:  "override: Definition and InputPath are taken from base",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := validator.ValidateLayered(tt.base, tt.override)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateLayered failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Definition != "#Worker" {
+				t.Errorf("Definition = %q, want #Worker", result.Definition)
+			}
+		})
+	}
+}
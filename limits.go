@@ -0,0 +1,78 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+)
+
+// inputLimits bounds the size of input values before they are evaluated
+// against the schema, protecting against adversarial inputs
+type inputLimits struct {
+	maxDepth  int
+	maxValues int
+}
+
+// newInputLimits resolves the configured limits, applying defaults
+func newInputLimits(opts ValidationOptions) inputLimits {
+	return inputLimits{
+		maxDepth:  resolveLimit(opts.MaxDepth, DefaultMaxDepth),
+		maxValues: resolveLimit(opts.MaxValues, DefaultMaxValues),
+	}
+}
+
+// resolveLimit maps 0 to the default and negative values to no limit
+func resolveLimit(configured int, defaultLimit int) int {
+	switch {
+	case configured == 0:
+		return defaultLimit
+	case configured < 0:
+		return 0
+	default:
+		return configured
+	}
+}
+
+// check walks value and returns an error as soon as a limit is exceeded
+func (l inputLimits) check(value cue.Value) error {
+	if l.maxDepth == 0 && l.maxValues == 0 {
+		return nil
+	}
+	count := 0
+	return l.walk(value, 1, &count)
+}
+
+// walk visits value and its children, tracking depth and the number of values
+func (l inputLimits) walk(value cue.Value, depth int, count *int) error {
+	*count++
+	if l.maxValues > 0 && *count > l.maxValues {
+		return fmt.Errorf("input exceeds the maximum of %d values", l.maxValues)
+	}
+	if l.maxDepth > 0 && depth > l.maxDepth {
+		return fmt.Errorf("input exceeds the maximum nesting depth of %d", l.maxDepth)
+	}
+
+	switch value.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := value.Fields()
+		if err != nil {
+			return nil
+		}
+		for iter.Next() {
+			if err := l.walk(iter.Value(), depth+1, count); err != nil {
+				return err
+			}
+		}
+	case cue.ListKind:
+		iter, err := value.List()
+		if err != nil {
+			return nil
+		}
+		for iter.Next() {
+			if err := l.walk(iter.Value(), depth+1, count); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
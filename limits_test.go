@@ -0,0 +1,80 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestInputLimits tests rejecting inputs that are too deep or too large
+func TestInputLimits(t *testing.T) {
+	schema := `#Node: {child?: #Node, items?: [...int]}
+#Config: #Node`
+	deep := strings.Repeat(`{"child": `, 20) + `{}` + strings.Repeat(`}`, 20)
+	wide := `{"items": [` + strings.Repeat(`1, `, 99) + `1]}`
+
+	tests := []struct {
+		name        string
+		opts        ValidationOptions
+		data        string
+		wantValid   bool
+		wantMessage string
+	}{
+		{name: "within defaults", data: deep, wantValid: true},
+		{name: "too deep", opts: ValidationOptions{MaxDepth: 10}, data: deep, wantMessage: "maximum nesting depth of 10"},
+		{name: "too many values", opts: ValidationOptions{MaxValues: 50}, data: wide, wantMessage: "maximum of 50 values"},
+		{name: "limits disabled", opts: ValidationOptions{MaxDepth: -1, MaxValues: -1}, data: deep, wantValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, tt.opts)
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "input.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Errors[0].Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", result.Errors[0].Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestInputLimitsOtherValues tests the limits on values that are not
+// parsed from a single input
+func TestInputLimitsOtherValues(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {child?: #Config, items?: [...int]}`, ValidationOptions{MaxValues: 5})
+	input := func(data string) ValidationInput {
+		return ValidationInput{SourceType: SourceBytes, Data: []byte(data), Format: FormatJSON, Name: "input.json"}
+	}
+
+	// Each side has 5 values, the merged value 9
+	result, _, err := validator.ValidateLayered(input(`{"items": [1, 2, 3]}`), input(`{"child": {"items": [1, 2]}}`))
+	if err != nil {
+		t.Fatalf("ValidateLayered failed: %v", err)
+	}
+	if result.Valid || !strings.Contains(result.Errors[0].Message, "maximum of 5 values") {
+		t.Errorf("ValidateLayered = %v, want the merged value rejected (errors: %v)", result.Valid, result.Errors)
+	}
+
+	_, _, err = validator.ValidateAndCompare(input(`{}`), []byte(`{"items": [1, 2, 3, 4, 5]}`))
+	if err == nil || !strings.Contains(err.Error(), "maximum of 5 values") {
+		t.Errorf("ValidateAndCompare error = %v, want the previous version rejected", err)
+	}
+
+	result, err = validator.ValidateField("items", []int{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatalf("ValidateField failed: %v", err)
+	}
+	if result.Valid || !strings.Contains(result.Errors[0].Message, "maximum of 5 values") {
+		t.Errorf("ValidateField = %v, want the value rejected (errors: %v)", result.Valid, result.Errors)
+	}
+}
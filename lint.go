@@ -0,0 +1,154 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+)
+
+// lintCheck inspects the parsed files and evaluated value of a schema
+// and reports findings
+type lintCheck func(files []*ast.File, schema cue.Value) []ValidationError
+
+// lintChecks lists the checks run by LintSchema, in order
+var lintChecks = []lintCheck{
+	lintFailingConstraints,
+	lintUnusedDefinitions,
+}
+
+// LintSchema checks a CUE schema file, or the package in a schema
+// directory, for structural problems without validating any data. The
+// schema is loaded like in NewValidator.
+//
+// Findings reuse ValidationError, with Path pointing at the offending
+// definition or field (e.g., "#Config.replicas") and Line/Column in the schema:
+//   - Fields whose constraints can never be satisfied (SeverityError)
+//   - Hidden definitions (e.g., "_#Base") that nothing references (SeverityWarning)
+//
+// Returns an error if the schema cannot be read or loaded (e.g., it has
+// syntax errors).
+func LintSchema(schemaPath string) ([]ValidationError, error) {
+	instance, err := loadSchemaInstance(schemaPath, ValidationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	schema := cuecontext.New().BuildInstance(instance)
+
+	var findings []ValidationError
+	for _, check := range lintChecks {
+		findings = append(findings, check(instance.Files, schema)...)
+	}
+	return findings, nil
+}
+
+// newValidatorVerbose creates a new Validator and lints its schema file,
+// reporting all findings as warnings
+func newValidatorVerbose(schemaPath string, definitionName string) (*Validator, []ValidationError, error) {
+	validator, err := newValidator(schemaPath, definitionName, ValidationOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []ValidationError
+	configDef, err := validator.definition()
+	if err != nil {
+		return nil, nil, err
+	}
+	if configDef.IncompleteKind() == cue.TopKind {
+		pos := configDef.Pos()
+		warnings = append(warnings, ValidationError{
+			Line:     pos.Line(),
+			Column:   pos.Column(),
+			Path:     definitionName,
+			Message:  fmt.Sprintf("definition %s accepts any value", definitionName),
+			Severity: SeverityWarning,
+		})
+	}
+
+	findings, err := LintSchema(schemaPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, finding := range findings {
+		finding.Severity = SeverityWarning
+		warnings = append(warnings, finding)
+	}
+
+	return validator, warnings, nil
+}
+
+// lintFailingConstraints reports definition fields that evaluate to an error
+// regardless of input (e.g., int & >10 & <5)
+func lintFailingConstraints(_ []*ast.File, schema cue.Value) []ValidationError {
+	var findings []ValidationError
+
+	iter, err := schema.Fields(cue.Definitions(true), cue.Hidden(true))
+	if err != nil {
+		return []ValidationError{{Message: err.Error()}}
+	}
+	for iter.Next() {
+		if !iter.Selector().IsDefinition() {
+			continue
+		}
+		for _, e := range errors.Errors(iter.Value().Validate()) {
+			findings = append(findings, ValidationError{
+				Line:    extractLineNumber(e, nil),
+				Column:  extractColumnNumber(e, nil),
+				Path:    strings.Join(e.Path(), "."),
+				Message: fmt.Sprintf("constraint can never be satisfied: %s", e.Error()),
+			})
+		}
+	}
+	return findings
+}
+
+// lintUnusedDefinitions reports hidden definitions that are never referenced
+// in any file of the package. Regular definitions are not reported since
+// they may be used from outside the schema (e.g., as the validation target).
+func lintUnusedDefinitions(files []*ast.File, _ cue.Value) []ValidationError {
+	var findings []ValidationError
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			field, ok := decl.(*ast.Field)
+			if !ok {
+				continue
+			}
+			ident, ok := field.Label.(*ast.Ident)
+			if !ok || !strings.HasPrefix(ident.Name, "_#") {
+				continue
+			}
+
+			// The label itself is an *ast.Ident too; only references elsewhere count
+			if countReferences(files, ident) == 0 {
+				pos := ident.Pos()
+				findings = append(findings, ValidationError{
+					Line:     pos.Line(),
+					Column:   pos.Column(),
+					Path:     ident.Name,
+					Message:  fmt.Sprintf("definition %s is never referenced", ident.Name),
+					Severity: SeverityWarning,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// countReferences counts identifiers with the same name as label, excluding label itself
+func countReferences(files []*ast.File, label *ast.Ident) int {
+	count := 0
+	for _, file := range files {
+		ast.Walk(file, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && ident != label && ident.Name == label.Name {
+				count++
+			}
+			return true
+		}, nil)
+	}
+	return count
+}
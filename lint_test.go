@@ -0,0 +1,144 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestLintSchema tests structural checks on a schema
+func TestLintSchema(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+	schema := `
+_#Base: {name: string}
+_#Unused: {id: int}
+
+#Config: {
+	_#Base
+	replicas: int & >10 & <5
+}
+`
+	if err := os.WriteFile(schemaPath, []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	findings, err := LintSchema(schemaPath)
+	if err != nil {
+		t.Fatalf("LintSchema failed: %v", err)
+	}
+
+	want := map[string]Severity{
+		"#Config.replicas": SeverityError,
+		"_#Unused":         SeverityWarning,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("got %d findings, want %d: %v", len(findings), len(want), findings)
+	}
+	for _, f := range findings {
+		severity, ok := want[f.Path]
+		if !ok {
+			t.Errorf("unexpected finding: %+v", f)
+			continue
+		}
+		if f.Severity != severity {
+			t.Errorf("%s: Severity = %v, want %v", f.Path, f.Severity, severity)
+		}
+		if f.Line == 0 {
+			t.Errorf("%s: Line should point into the schema", f.Path)
+		}
+	}
+
+	// Syntax errors are process errors
+	brokenPath := filepath.Join(t.TempDir(), "broken.cue")
+	if err := os.WriteFile(brokenPath, []byte(`#Config: {`), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+	if _, err := LintSchema(brokenPath); err == nil {
+		t.Error("expected error for schema with syntax errors")
+	}
+}
+
+// TestNewValidatorVerbose tests schema warnings returned at construction
+func TestNewValidatorVerbose(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    string
+		wantPaths []string
+	}{
+		{
+			name:   "clean schema",
+			schema: `#Config: {name: string}`,
+		},
+		{
+			name: "unused hidden definition",
+			schema: `_#Unused: {id: int}
+#Config: {name: string}`,
+			wantPaths: []string{"_#Unused"},
+		},
+		{
+			name:      "definition accepts anything",
+			schema:    `#Config: _`,
+			wantPaths: []string{"#Config"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+			if err := os.WriteFile(schemaPath, []byte(tt.schema), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			validator, warnings, err := NewValidatorVerbose(schemaPath, "#Config")
+			if err != nil {
+				t.Fatalf("NewValidatorVerbose failed: %v", err)
+			}
+			if validator == nil {
+				t.Fatal("validator is nil")
+			}
+
+			var gotPaths []string
+			for _, w := range warnings {
+				if w.Severity != SeverityWarning {
+					t.Errorf("%s: Severity = %v, want warning", w.Path, w.Severity)
+				}
+				gotPaths = append(gotPaths, w.Path)
+			}
+			if !slices.Equal(gotPaths, tt.wantPaths) {
+				t.Errorf("warnings at %v, want %v", gotPaths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+// TestLintSchemaDirectory tests linting the package in a schema directory,
+// with hidden definitions referenced from other files of the package
+func TestLintSchemaDirectory(t *testing.T) {
+	schemaDir := t.TempDir()
+	files := map[string]string{
+		"base.cue":   "package schemas\n\n_#Base: {name: string}\n_#Unused: {id: int}\n",
+		"config.cue": "package schemas\n\n#Config: {\n\t_#Base\n\treplicas: int\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(schemaDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	findings, err := LintSchema(schemaDir)
+	if err != nil {
+		t.Fatalf("LintSchema failed: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Path != "_#Unused" {
+		t.Errorf("findings = %v, want one for _#Unused", findings)
+	}
+
+	validator, warnings, err := NewValidatorVerbose(schemaDir, "#Config")
+	if err != nil {
+		t.Fatalf("NewValidatorVerbose failed: %v", err)
+	}
+	if validator == nil || len(warnings) != 1 || warnings[0].Path != "_#Unused" {
+		t.Errorf("warnings = %v, want one for _#Unused", warnings)
+	}
+}
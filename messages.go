@@ -0,0 +1,80 @@
+package cuebridge
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// listLengthPattern matches the builtin message of list.MinItems and
+// list.MaxItems (e.g., "len(list) < MinItems(1) (0 < 1)")
+var listLengthPattern = regexp.MustCompile(`len\(list\) [<>] (Min|Max)Items\((\d+)\) \((\d+) [<>] \d+\)`)
+
+// boundPattern matches a value outside a bound (e.g., "invalid value 0 (out of bound >0)")
+var boundPattern = regexp.MustCompile(`invalid value (.+) \(out of bound ([^()]+)\)$`)
+
+// regexpBoundPattern matches a string that fails a regular expression
+// constraint (e.g., `invalid value "Foo" (out of bound =~"^[a-z]+$")`)
+var regexpBoundPattern = regexp.MustCompile(`invalid value (".*") \(out of bound ([=!])~(".*")\)$`)
+
+// rewriteMessage replaces CUE's message for well-known constraint
+// violations with a plainer one, or returns message unchanged.
+// errPath is the CUE error path and source the input, if known, used to
+// tell a field that is missing from one whose value is wrong.
+func rewriteMessage(message string, errPath []string, source *sourceContext) string {
+	path := formatPath(errPath)
+	if match := listLengthPattern.FindStringSubmatch(message); match != nil {
+		return listLengthMessage(match[1], match[2], match[3], path)
+	}
+	if match := regexpBoundPattern.FindStringSubmatch(message); match != nil {
+		return regexpMessage(match[1], match[2], match[3])
+	}
+	if match := boundPattern.FindStringSubmatch(message); match != nil {
+		return fmt.Sprintf("value %s does not satisfy %s", match[1], match[2])
+	}
+	if strings.Contains(message, "incomplete value ") && isMissingField(errPath, source) {
+		return "missing required field"
+	}
+	return message
+}
+
+// isMissingField reports whether the input has no value at the error path,
+// i.e. an incomplete value comes from the schema alone
+func isMissingField(errPath []string, source *sourceContext) bool {
+	if source == nil || !source.value.Exists() || formatPath(errPath) == "" {
+		return false
+	}
+	return !source.value.LookupPath(inputPath(errPath)).Exists()
+}
+
+// regexpMessage describes a =~ or !~ violation with the pattern unquoted,
+// e.g. `value "Foo" does not match pattern ^[a-z]+$`
+func regexpMessage(value string, operator string, quotedPattern string) string {
+	pattern, err := strconv.Unquote(quotedPattern)
+	if err != nil {
+		pattern = quotedPattern
+	}
+	if operator == "!" {
+		return fmt.Sprintf("value %s must not match pattern %s", value, pattern)
+	}
+	return fmt.Sprintf("value %s does not match pattern %s", value, pattern)
+}
+
+// listLengthMessage describes a list.MinItems or list.MaxItems violation,
+// e.g. "list at tags must have at least 1 item (got 0)"
+func listLengthMessage(bound string, limit string, got string, path string) string {
+	subject := "list"
+	if path != "" {
+		subject = "list at " + path
+	}
+	quantifier := "at least"
+	if bound == "Max" {
+		quantifier = "at most"
+	}
+	items := "items"
+	if n, _ := strconv.Atoi(limit); n == 1 {
+		items = "item"
+	}
+	return fmt.Sprintf("%s must have %s %s %s (got %s)", subject, quantifier, limit, items, got)
+}
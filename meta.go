@@ -0,0 +1,71 @@
+package cuebridge
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"cuelang.org/go/cue"
+)
+
+// ValidateCUEAgainst validates a CUE file itself, treated as data, against a
+// definition in a meta-schema. This checks schema authoring conventions,
+// e.g. that every definition has a description:
+//
+//	#Meta: {
+//		[=~"^#"]: {description!: string, ...}
+//		...
+//	}
+//
+// The CUE file's top-level regular fields and definitions become fields of
+// the data, keyed by their label (e.g., "#Service"). Their values need not be
+// concrete, since schemas contain types, but required fields (field!) in the
+// meta-schema must be present. The result is named by the file's base name.
+//
+// Returns an error if either file fails to load or the meta-schema
+// does not define definitionName.
+func ValidateCUEAgainst(cuePath, metaSchemaPath, definitionName string) (ValidationResult, error) {
+	validator, err := newValidator(metaSchemaPath, definitionName, ValidationOptions{})
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	target, err := compileSchemaFile(validator.ctx, cuePath, ValidationOptions{})
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	data, err := schemaAsData(target)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	metaDef, err := validator.definition()
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	source := &sourceContext{name: filepath.Base(cuePath), value: data}
+	if err := metaDef.Unify(data).Validate(cue.Final()); err != nil {
+		return validator.withProvenance(createValidationErrorResult(source, err)), nil
+	}
+
+	return validator.withProvenance(ValidationResult{
+		Name:   source.name,
+		Valid:  true,
+		Errors: []ValidationError{},
+	}), nil
+}
+
+// schemaAsData returns a struct holding the top-level fields and definitions
+// of a schema as regular fields, keyed by their label
+func schemaAsData(schema cue.Value) (cue.Value, error) {
+	iter, err := schema.Fields(cue.Definitions(true))
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("reading schema fields: %w", err)
+	}
+
+	data := schema.Context().CompileString("{}")
+	for iter.Next() {
+		data = data.FillPath(cue.MakePath(cue.Str(iter.Selector().String())), iter.Value())
+	}
+	return data, nil
+}
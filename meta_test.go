@@ -0,0 +1,76 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidateCUEAgainst tests checking schema files against a meta-schema
+func TestValidateCUEAgainst(t *testing.T) {
+	tmpDir := t.TempDir()
+	metaPath := filepath.Join(tmpDir, "meta.cue")
+	meta := `#Meta: {
+	[=~"^#"]: {description!: string, ...}
+	...
+}`
+	if err := os.WriteFile(metaPath, []byte(meta), 0644); err != nil {
+		t.Fatalf("failed to write meta-schema: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		schema    string
+		wantValid bool
+		wantPath  string
+	}{
+		{
+			name: "all definitions described",
+			schema: `#Service: {
+	description: "A deployable service"
+	port:        int & >0
+}
+_internal: true
+`,
+			wantValid: true,
+		},
+		{
+			name: "missing description",
+			schema: `#Service: {
+	description: "A deployable service"
+	port:        int
+}
+#Job: {schedule: string}
+`,
+			wantValid: false,
+			wantPath:  "#Job.description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaPath := filepath.Join(t.TempDir(), "service.cue")
+			if err := os.WriteFile(schemaPath, []byte(tt.schema), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			result, err := ValidateCUEAgainst(schemaPath, metaPath, "#Meta")
+			if err != nil {
+				t.Fatalf("ValidateCUEAgainst failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Name != "service.cue" || result.SchemaPath != metaPath {
+				t.Errorf("Name, SchemaPath = %q, %q", result.Name, result.SchemaPath)
+			}
+			if tt.wantPath != "" && (len(result.Errors) != 1 || result.Errors[0].Path != tt.wantPath) {
+				t.Errorf("errors = %v, want one at %q", result.Errors, tt.wantPath)
+			}
+		})
+	}
+
+	if _, err := ValidateCUEAgainst(filepath.Join(tmpDir, "missing.cue"), metaPath, "#Meta"); err == nil {
+		t.Error("expected error for missing CUE file")
+	}
+}
@@ -0,0 +1,59 @@
+package cuebridge
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/mod/modconfig"
+	"cuelang.org/go/mod/module"
+)
+
+// newValidatorFromModule fetches a CUE module from its registry and creates
+// a new Validator from one of its packages
+func newValidatorFromModule(modulePath string, packageName string, definitionName string) (*Validator, error) {
+	version, err := module.ParseVersion(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module %s: %w", modulePath, err)
+	}
+
+	// The registry configuration comes from CUE_REGISTRY and related
+	// environment variables, and fetched modules are kept in the CUE cache
+	registry, err := modconfig.NewRegistry(nil)
+	if err != nil {
+		return nil, fmt.Errorf("configuring registry: %w", err)
+	}
+
+	location, err := registry.Fetch(context.Background(), version)
+	if err != nil {
+		return nil, fmt.Errorf("fetching module %s: %w", modulePath, err)
+	}
+	rootFS, ok := location.FS.(module.OSRootFS)
+	if !ok || rootFS.OSRoot() == "" {
+		return nil, fmt.Errorf("fetching module %s: module is not in the local cache", modulePath)
+	}
+	moduleDir := filepath.Join(rootFS.OSRoot(), filepath.FromSlash(location.Dir))
+
+	instances := load.Instances([]string{"./" + path.Clean(packageName)}, &load.Config{
+		Dir:      moduleDir,
+		Registry: registry,
+	})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("loading schema: expected 1 instance, got %d", len(instances))
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
+	}
+
+	schema := cuecontext.New().BuildInstance(instances[0])
+	if schema.Err() != nil {
+		return nil, fmt.Errorf("compiling schema: %w", schema.Err())
+	}
+
+	// Record the package's import path (e.g., "example.com/schemas/k8s@v0.1.0")
+	schemaPath := path.Join(version.BasePath(), packageName) + "@" + version.Version()
+	return newValidatorFromValue(schema, schemaPath, definitionName, ValidationOptions{})
+}
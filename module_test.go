@@ -0,0 +1,81 @@
+package cuebridge
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"cuelang.org/go/mod/modcache"
+	"cuelang.org/go/mod/modregistrytest"
+)
+
+// TestNewValidatorFromModule tests loading a schema from a registry module
+func TestNewValidatorFromModule(t *testing.T) {
+	registry, err := modregistrytest.New(fstest.MapFS{
+		"example.com_schemas_v0.1.0/cue.mod/module.cue": {Data: []byte(`module: "example.com/schemas@v0"
+language: version: "v0.9.0"
+`)},
+		"example.com_schemas_v0.1.0/root.cue": {Data: []byte("package schemas\n\n#Config: {name: string}\n")},
+		"example.com_schemas_v0.1.0/apps/apps.cue": {Data: []byte(`package apps
+
+import "example.com/schemas@v0:schemas"
+
+#Service: {config: schemas.#Config, port: int & >0}
+`)},
+	}, "")
+	if err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	defer registry.Close()
+
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("CUE_CONFIG_DIR", dir)
+	t.Setenv("CUE_REGISTRY", registry.Host()+"+insecure")
+	t.Setenv("CUE_CACHE_DIR", cacheDir)
+	t.Cleanup(func() {
+		// Cached modules are read-only
+		modcache.RemoveAll(cacheDir)
+	})
+
+	validator, err := NewValidatorFromModule("example.com/schemas@v0.1.0", "apps", "#Service")
+	if err != nil {
+		t.Fatalf("NewValidatorFromModule failed: %v", err)
+	}
+
+	for data, wantValid := range map[string]bool{
+		`{"config": {"name": "api"}, "port": 8080}`: true,
+		`{"config": {"name": 1}, "port": 8080}`:     false,
+	} {
+		result, err := validator.Validate(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(data),
+			Format:     FormatJSON,
+			Name:       "service.json",
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if result.Valid != wantValid {
+			t.Errorf("%s: Valid = %v, want %v (errors: %v)", data, result.Valid, wantValid, result.Errors)
+		}
+		if result.SchemaPath != "example.com/schemas/apps@v0.1.0" {
+			t.Errorf("SchemaPath = %q", result.SchemaPath)
+		}
+	}
+
+	if _, err := NewValidatorFromModule("example.com/schemas@v0.1.0", "", "#Config"); err != nil {
+		t.Errorf("loading the module root failed: %v", err)
+	}
+
+	_, err = NewValidatorFromModule("example.com/missing@v0.1.0", "", "#Config")
+	if err == nil || !strings.Contains(err.Error(), "fetching module example.com/missing@v0.1.0") {
+		t.Errorf("error = %v, want fetch error", err)
+	}
+
+	if _, err := NewValidatorFromModule("example.com/schemas", "", "#Config"); err == nil {
+		t.Error("expected error for module path without version")
+	}
+}
@@ -0,0 +1,63 @@
+package cuebridge
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"cuelang.org/go/cue"
+)
+
+// applyOverrides unifies the overrides into the definition of schema.
+// Each key is a field path relative to the definition (e.g., "spec.replicas")
+// and each value is converted according to the field's type in the schema.
+// Overrides are applied in key order, so errors are deterministic.
+func applyOverrides(schema cue.Value, definitionName string, overrides map[string]string) (cue.Value, error) {
+	definitionPath := cue.ParsePath(definitionName)
+
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		raw := overrides[key]
+
+		fieldPath := cue.ParsePath(key)
+		if fieldPath.Err() != nil {
+			return cue.Value{}, fmt.Errorf("override %s=%s: invalid path: %w", key, raw, fieldPath.Err())
+		}
+
+		path := cue.MakePath(append(definitionPath.Selectors(), fieldPath.Selectors()...)...)
+		field := schema.LookupPath(path)
+		if !field.Exists() {
+			return cue.Value{}, fmt.Errorf("override %s=%s: %s does not define %s", key, raw, definitionName, key)
+		}
+
+		value, err := overrideValue(schema.Context(), field, raw)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("override %s=%s: %w", key, raw, err)
+		}
+		if err := field.Unify(value).Validate(); err != nil {
+			return cue.Value{}, fmt.Errorf("override %s=%s: %w", key, raw, err)
+		}
+
+		schema = schema.FillPath(path, value)
+	}
+
+	return schema, nil
+}
+
+// overrideValue converts a raw override string into a value of the
+// field's type: strings are taken literally, anything else is parsed as a
+// CUE literal (e.g., 5, true, [1, 2]).
+func overrideValue(ctx *cue.Context, field cue.Value, raw string) (cue.Value, error) {
+	kind := field.IncompleteKind()
+	if kind == cue.StringKind {
+		return ctx.Encode(raw), nil
+	}
+
+	value := ctx.CompileString(raw)
+	if value.Err() != nil || value.IncompleteKind()&kind == 0 {
+		if kind&cue.StringKind != 0 {
+			return ctx.Encode(raw), nil
+		}
+		return cue.Value{}, fmt.Errorf("want %s", kind)
+	}
+	return value, nil
+}
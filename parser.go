@@ -1,39 +1,189 @@
 package cuebridge
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/token"
 	"cuelang.org/go/encoding/json"
+	"cuelang.org/go/encoding/toml"
 	"cuelang.org/go/encoding/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
+// parseOptions controls format-specific parsing behavior
+type parseOptions struct {
+	// proto3JSON applies proto3 JSON mapping conventions to JSON input
+	proto3JSON bool
+	// yamlTags maps custom YAML tags to their handlers
+	yamlTags map[string]YAMLTagHandler
+	// keyCase renames object keys before the value is built
+	keyCase KeyCase
+}
+
 // parseData parses data into a CUE value based on format
-func parseData(ctx *cue.Context, data []byte, format DataFormat, filename string) (cue.Value, error) {
+func parseData(ctx *cue.Context, data []byte, format DataFormat, filename string, opts parseOptions) (cue.Value, error) {
 	switch format {
 	case FormatJSON:
-		return parseJSON(ctx, data, filename)
+		return parseJSON(ctx, data, filename, opts)
 	case FormatYAML:
-		return parseYAML(ctx, data, filename)
+		return parseYAML(ctx, data, filename, opts)
+	case FormatTOML:
+		return parseTOML(ctx, data, filename, opts)
+	case FormatJSONC:
+		return parseJSON(ctx, stripJSONC(data), filename, opts)
 	default:
 		return cue.Value{}, fmt.Errorf("unsupported format: %d", format)
 	}
 }
 
 // parseJSON parses JSON data into a CUE value
-func parseJSON(ctx *cue.Context, data []byte, filename string) (cue.Value, error) {
+func parseJSON(ctx *cue.Context, data []byte, filename string, opts parseOptions) (cue.Value, error) {
 	expr, err := json.Extract(filename, data)
 	if err != nil {
 		return cue.Value{}, fmt.Errorf("parsing JSON: %w", err)
 	}
+	if opts.proto3JSON {
+		removeNullFields(expr)
+	}
+	normalizeKeyCase(expr, opts.keyCase)
+	return ctx.BuildExpr(expr), nil
+}
+
+// removeNullFields removes object fields whose value is null, recursively.
+// In proto3 JSON, null means the field is absent (set to its default).
+func removeNullFields(expr ast.Expr) {
+	switch x := expr.(type) {
+	case *ast.StructLit:
+		elts := x.Elts[:0]
+		for _, elt := range x.Elts {
+			if field, ok := elt.(*ast.Field); ok {
+				if lit, ok := field.Value.(*ast.BasicLit); ok && lit.Kind == token.NULL {
+					continue
+				}
+				removeNullFields(field.Value)
+			}
+			elts = append(elts, elt)
+		}
+		x.Elts = elts
+	case *ast.ListLit:
+		for _, elt := range x.Elts {
+			removeNullFields(elt)
+		}
+	}
+}
+
+// parseTOML parses TOML data into a CUE value
+func parseTOML(ctx *cue.Context, data []byte, filename string, opts parseOptions) (cue.Value, error) {
+	expr, err := toml.NewDecoder(filename, bytes.NewReader(data)).Decode()
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("parsing TOML: %w", err)
+	}
+	normalizeKeyCase(expr, opts.keyCase)
 	return ctx.BuildExpr(expr), nil
 }
 
 // parseYAML parses YAML data into a CUE value
-func parseYAML(ctx *cue.Context, data []byte, filename string) (cue.Value, error) {
+func parseYAML(ctx *cue.Context, data []byte, filename string, opts parseOptions) (cue.Value, error) {
+	var replacements map[yamlTagSite]ast.Expr
+	if len(opts.yamlTags) > 0 {
+		resolved, tagged, err := resolveYAMLTags(data, filename, opts.yamlTags)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("parsing YAML: %w", err)
+		}
+		data, replacements = resolved, tagged
+	}
+
 	file, err := yaml.Extract(filename, data)
 	if err != nil {
+		// The extractor's message for tab indentation is cryptic
+		// and may point at the wrong line
+		if tabErr := checkTabIndentation(data, filename); tabErr != nil {
+			return cue.Value{}, fmt.Errorf("parsing YAML: %w", tabErr)
+		}
+		// Likewise for custom tags, which the extractor cannot decode
+		if _, _, tagErr := resolveYAMLTags(data, filename, nil); tagErr != nil {
+			return cue.Value{}, fmt.Errorf("parsing YAML: %w", tagErr)
+		}
 		return cue.Value{}, fmt.Errorf("parsing YAML: %w", err)
 	}
+	if len(replacements) > 0 {
+		replaceTaggedValues(file, replacements)
+	}
+	normalizeKeyCase(file, opts.keyCase)
 	return ctx.BuildFile(file), nil
 }
+
+// findDuplicateYAMLKeys returns an error for every mapping key that repeats
+// an earlier key of the same mapping, at the line of the repetition.
+// The extractor silently accepts repeated keys whose values unify.
+// Syntax errors are left to the extractor.
+func findDuplicateYAMLKeys(data []byte) []ValidationError {
+	var duplicates []ValidationError
+	decoder := yamlv3.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yamlv3.Node
+		if err := decoder.Decode(&doc); err != nil {
+			return duplicates
+		}
+		duplicates = appendDuplicateYAMLKeys(duplicates, &doc, nil)
+	}
+}
+
+// appendDuplicateYAMLKeys appends the repeated keys in node and its children
+func appendDuplicateYAMLKeys(duplicates []ValidationError, node *yamlv3.Node, path []string) []ValidationError {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		for _, child := range node.Content {
+			duplicates = appendDuplicateYAMLKeys(duplicates, child, path)
+		}
+	case yamlv3.SequenceNode:
+		for i, child := range node.Content {
+			duplicates = appendDuplicateYAMLKeys(duplicates, child, append(path, strconv.Itoa(i)))
+		}
+	case yamlv3.MappingNode:
+		firstLines := map[string]int{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := append(slices.Clip(path), key.Value)
+			if key.Kind == yamlv3.ScalarNode && key.Value != "<<" {
+				if firstLine, ok := firstLines[key.Value]; ok {
+					duplicates = append(duplicates, ValidationError{
+						Line:    key.Line,
+						Column:  key.Column,
+						Path:    formatPath(keyPath),
+						Pointer: jsonPointer(keyPath),
+						Message: fmt.Sprintf("duplicate key %q (first defined at line %d)", key.Value, firstLine),
+					})
+				} else {
+					firstLines[key.Value] = key.Line
+				}
+			}
+			duplicates = appendDuplicateYAMLKeys(duplicates, value, keyPath)
+		}
+	}
+	return duplicates
+}
+
+// checkTabIndentation returns an error for the first line indented with a tab
+func checkTabIndentation(data []byte, filename string) error {
+	offset := 0
+	for i, line := range strings.SplitAfter(string(data), "\n") {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		content := strings.TrimSpace(line)
+		if content != "" && strings.Contains(indent, "\t") {
+			file := token.NewFile(filename, -1, len(data))
+			file.SetLinesForContent(data)
+			pos := file.Pos(offset+strings.Index(indent, "\t"), token.NoRelPos)
+			return errors.Newf(pos, "tabs are not allowed for indentation at line %d", i+1)
+		}
+		offset += len(line)
+	}
+	return nil
+}
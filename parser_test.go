@@ -0,0 +1,424 @@
+package cuebridge
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/ast"
+	"gopkg.in/yaml.v3"
+)
+
+// TestYAMLTabIndentation tests the error for tab-indented YAML
+func TestYAMLTabIndentation(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string, spec: {replicas: int}}`)
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nspec:\n  kind: web\n\treplicas: 1\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if result.Valid || len(result.Errors) != 1 {
+		t.Fatalf("expected a single parse error, got %+v", result)
+	}
+	e := result.Errors[0]
+	if !strings.Contains(e.Message, "tabs are not allowed for indentation at line 4") {
+		t.Errorf("Message = %q, want tab indentation error", e.Message)
+	}
+	if e.Line != 4 {
+		t.Errorf("Line = %d, want 4", e.Line)
+	}
+}
+
+// TestProto3JSON tests proto3 JSON null handling
+func TestProto3JSON(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string, labels?: [string]: string, spec?: {replicas?: int}}`)
+	data := []byte(`{"name": "app", "labels": null, "spec": {"replicas": null}}`)
+
+	tests := []struct {
+		name       string
+		proto3JSON bool
+		wantValid  bool
+	}{
+		{name: "plain JSON rejects null", proto3JSON: false, wantValid: false},
+		{name: "proto3 JSON treats null as absent", proto3JSON: true, wantValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       data,
+				Format:     FormatJSON,
+				Name:       "payload.json",
+				Proto3JSON: tt.proto3JSON,
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+		})
+	}
+}
+
+// TestYAMLTagHandlers tests mapping custom YAML tags to CUE expressions
+func TestYAMLTagHandlers(t *testing.T) {
+	schema := `#Ref: {Ref: string}
+#Config: {
+	bucket: #Ref
+	name:   string | {"Fn::Sub": string}
+	port:   int & >0
+	ключ?:  #Ref
+}`
+	handlers := map[string]YAMLTagHandler{
+		"!Ref": func(node *yaml.Node) (ast.Expr, error) {
+			return ast.NewStruct("Ref", ast.NewString(node.Value)), nil
+		},
+		"!Sub": func(node *yaml.Node) (ast.Expr, error) {
+			return ast.NewStruct(ast.NewString("Fn::Sub"), ast.NewString(node.Value)), nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		handlers    map[string]YAMLTagHandler
+		data        string
+		wantValid   bool
+		wantLine    int
+		wantMessage string
+	}{
+		{
+			name:      "tags resolved",
+			handlers:  handlers,
+			data:      "bucket: !Ref MyBucket\nname: !Sub ${AWS::StackName}-app\nport: 80\n",
+			wantValid: true,
+		},
+		{
+			name:      "non-ASCII keys",
+			handlers:  handlers,
+			data:      "ключ: !Ref B\nbucket: !Ref MyBucket\nname: app\nport: 80\n",
+			wantValid: true,
+		},
+		{
+			name:      "positions kept",
+			handlers:  handlers,
+			data:      "bucket: !Ref MyBucket\nname: !Sub app\nport: 0\n",
+			wantValid: false,
+			wantLine:  3,
+		},
+		{
+			name:        "unknown tag",
+			handlers:    handlers,
+			data:        "bucket: !GetAtt Bucket.Arn\nname: app\nport: 80\n",
+			wantValid:   false,
+			wantLine:    1,
+			wantMessage: "unknown YAML tag !GetAtt at line 1",
+		},
+		{
+			name:        "no handlers",
+			data:        "name: app\nport: 80\nbucket: !Ref MyBucket\n",
+			wantValid:   false,
+			wantLine:    3,
+			wantMessage: "unknown YAML tag !Ref at line 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, ValidationOptions{
+				YAMLTagHandlers:      tt.handlers,
+				PreferInputPositions: true,
+			})
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatYAML,
+				Name:       "template.yaml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantValid {
+				return
+			}
+			e := result.Errors[0]
+			if e.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d (errors: %v)", e.Line, tt.wantLine, result.Errors)
+			}
+			if !strings.Contains(e.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", e.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestStrictYAML tests reporting repeated YAML mapping keys
+func TestStrictYAML(t *testing.T) {
+	schema := `#Config: {name: string, spec: {replicas: int, ports: [...{port: int}]}}`
+	data := []byte(`name: app
+spec:
+  replicas: 2
+  ports:
+    - port: 80
+      port: 80
+  replicas: 2
+`)
+
+	tests := []struct {
+		name       string
+		strictYAML bool
+		wantLines  []int
+		wantPaths  []string
+	}{
+		{name: "default accepts repeated keys", strictYAML: false},
+		{
+			name:       "strict reports repeated keys",
+			strictYAML: true,
+			wantLines:  []int{6, 7},
+			wantPaths:  []string{"spec.ports.0.port", "spec.replicas"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, ValidationOptions{StrictYAML: tt.strictYAML})
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       data,
+				Format:     FormatYAML,
+				Name:       "config.yaml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != (len(tt.wantLines) == 0) {
+				t.Fatalf("Valid = %v (errors: %v)", result.Valid, result.Errors)
+			}
+			if len(result.Errors) != len(tt.wantLines) {
+				t.Fatalf("got %d errors, want %d: %v", len(result.Errors), len(tt.wantLines), result.Errors)
+			}
+			for i, e := range result.Errors {
+				if e.Line != tt.wantLines[i] || e.Path != tt.wantPaths[i] {
+					t.Errorf("error %d at line %d, path %q; want line %d, path %q", i, e.Line, e.Path, tt.wantLines[i], tt.wantPaths[i])
+				}
+				if !strings.Contains(e.Message, "duplicate key") {
+					t.Errorf("Message = %q, want duplicate key error", e.Message)
+				}
+			}
+		})
+	}
+}
+
+// TestCheckSyntax tests parse-only syntax checking
+func TestCheckSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		format   DataFormat
+		file     string
+		wantLine int
+		wantOK   bool
+	}{
+		{name: "valid json", data: `{"port": 8080}`, format: FormatJSON, file: "config.json", wantOK: true},
+		{name: "valid yaml", data: "port: 8080\n", format: FormatYAML, file: "config.yaml", wantOK: true},
+		{name: "invalid json", data: "{\n  \"port\": 8080,\n}", format: FormatJSON, file: "config.json", wantLine: 3},
+		{name: "invalid yaml", data: "name: app\nspec:\n  kind: web\n\treplicas: 1\n", format: FormatYAML, file: "config.yaml", wantLine: 4},
+		{name: "auto detects from name", data: "{\"port\": }", format: FormatAuto, file: "config.json", wantLine: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := CheckSyntax([]byte(tt.data), tt.format, tt.file)
+			if tt.wantOK {
+				if errs != nil {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 {
+				t.Fatal("expected syntax errors, got none")
+			}
+			if errs[0].Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d (errors: %v)", errs[0].Line, tt.wantLine, errs)
+			}
+		})
+	}
+}
+
+// TestParseFailed tests distinguishing parse failures from schema violations
+func TestParseFailed(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string, port: int}`, ValidationOptions{StrictYAML: true})
+
+	tests := []struct {
+		name            string
+		data            string
+		format          DataFormat
+		wantParseFailed bool
+	}{
+		{name: "invalid json", data: `{"name": }`, format: FormatJSON, wantParseFailed: true},
+		{name: "tab indentation", data: "name: app\nspec:\n  kind: web\n\tport: 1\n", format: FormatYAML, wantParseFailed: true},
+		{name: "duplicate key", data: "name: app\nname: app\nport: 1\n", format: FormatYAML, wantParseFailed: true},
+		{name: "schema violation", data: `{"name": "app", "port": "http"}`, format: FormatJSON},
+		{name: "valid", data: `{"name": "app", "port": 80}`, format: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     tt.format,
+				Name:       "config",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.ParseFailed != tt.wantParseFailed {
+				t.Errorf("ParseFailed = %v, want %v (errors: %v)", result.ParseFailed, tt.wantParseFailed, result.Errors)
+			}
+			if tt.wantParseFailed && result.Valid {
+				t.Error("parse failure should be invalid")
+			}
+		})
+	}
+}
+
+// TestTOMLArraysOfTables tests that TOML arrays of tables become lists of structs
+func TestTOMLArraysOfTables(t *testing.T) {
+	validator := newTestValidator(t, `#Server: {host: string, port: int & >0, tags?: [...string]}
+#Config: {
+	name: string
+	servers: [...#Server]
+	database?: {replicas?: [...{host: string}]}
+}`)
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPath  string
+		wantLine  int
+	}{
+		{
+			name:      "arrays of tables",
+			data:      "name = \"app\"\n\n[[servers]]\nhost = \"a\"\nport = 80\n\n[[servers]]\nhost = \"b\"\nport = 81\ntags = [\"edge\"]\n",
+			wantValid: true,
+		},
+		{
+			name:      "nested array of tables",
+			data:      "name = \"app\"\nservers = []\n\n[[database.replicas]]\nhost = \"r1\"\n\n[[database.replicas]]\nhost = \"r2\"\n",
+			wantValid: true,
+		},
+		{
+			name:     "invalid element",
+			data:     "name = \"app\"\n\n[[servers]]\nhost = \"a\"\nport = 80\n\n[[servers]]\nhost = \"b\"\nport = 0\n",
+			wantPath: "servers.1.port",
+		},
+		{
+			name:     "syntax error",
+			data:     "name = \"app\"\n[[servers]\n",
+			wantLine: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatAuto,
+				Name:       "config.toml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantPath != "" && (len(result.Errors) != 1 || result.Errors[0].Path != tt.wantPath) {
+				t.Errorf("expected one error at %s, got %v", tt.wantPath, result.Errors)
+			}
+			if tt.wantLine != 0 && (!result.ParseFailed || result.Errors[0].Line != tt.wantLine) {
+				t.Errorf("expected a parse error at line %d, got %v", tt.wantLine, result.Errors)
+			}
+		})
+	}
+}
+
+// TestJSONC tests JSON with comments and trailing commas
+func TestJSONC(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string, port: int & >0, tags: [...string]}`, ValidationOptions{PreferInputPositions: true})
+
+	tests := []struct {
+		name       string
+		data       string
+		wantValid  bool
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:      "line and block comments",
+			data:      "{\n  // the service name\n  \"name\": \"app\", /* inline */ \"port\": 80,\n  /* multi\n     line */\n  \"tags\": [\"a\"]\n}\n",
+			wantValid: true,
+		},
+		{
+			name:      "trailing commas",
+			data:      "{\n  \"name\": \"app\",\n  \"port\": 80,\n  \"tags\": [\"a\", \"b\",],\n}\n",
+			wantValid: true,
+		},
+		{
+			name:      "comment markers inside strings",
+			data:      `{"name": "http://example.com/*x*/", "port": 80, "tags": ["a,]"]}`,
+			wantValid: true,
+		},
+		{
+			name:       "positions after comments",
+			data:       "{\n  /* header */ \"name\": \"app\", // comment\n  /* x */ \"port\": 0,\n  \"tags\": [],\n}\n",
+			wantLine:   3,
+			wantColumn: 19,
+		},
+		{
+			name:     "unterminated block comment",
+			data:     "{\n  \"name\": \"app\" /* open\n}\n",
+			wantLine: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSONC,
+				Name:       "settings.jsonc",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, errors: %v", result.Valid, result.Errors)
+			}
+			if tt.wantValid {
+				return
+			}
+			e := result.Errors[0]
+			if e.Line != tt.wantLine || (tt.wantColumn != 0 && e.Column != tt.wantColumn) {
+				t.Errorf("error at %d:%d, want %d:%d (%v)", e.Line, e.Column, tt.wantLine, tt.wantColumn, e)
+			}
+		})
+	}
+
+	if format, ok := formatFromExtension("settings.jsonc"); !ok || format != FormatJSONC {
+		t.Errorf("formatFromExtension(settings.jsonc) = %d, %v, want FormatJSONC", format, ok)
+	}
+}
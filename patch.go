@@ -0,0 +1,13 @@
+package cuebridge
+
+// ValidatePatch validates a partial document, such as a Kubernetes strategic
+// merge patch, against the schema. Every field present in the input must
+// conform to the schema and unknown fields are rejected as usual, but fields
+// absent from the input are not required, including required fields (field!).
+//
+// A patch is checked exactly like an input of ValidateStructural; this
+// method names that use.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidatePatch(input ValidationInput) (ValidationResult, error) {
+	return v.ValidateStructural(input)
+}
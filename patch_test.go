@@ -0,0 +1,158 @@
+package cuebridge
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue"
+)
+
+// TestValidatePatch tests validating partial documents
+func TestValidatePatch(t *testing.T) {
+	validator := newTestValidator(t, `#Container: {
+	name!:  string
+	image!: string
+	ports?: [...{containerPort: int & >0 & <65536}]
+}
+#Config: {
+	apiVersion: "apps/v1"
+	kind:       "Deployment"
+	metadata: {name!: string, labels?: [string]: string}
+	spec: {
+		replicas: int & >=0
+		template: spec: containers: [...#Container]
+	}
+}`)
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPath  string
+	}{
+		{
+			name:      "replicas only",
+			data:      "spec:\n  replicas: 3\n",
+			wantValid: true,
+		},
+		{
+			name:      "partial container",
+			data:      "spec:\n  template:\n    spec:\n      containers:\n        - image: nginx:1.27\n",
+			wantValid: true,
+		},
+		{
+			name:      "wrong type",
+			data:      "spec:\n  replicas: three\n",
+			wantValid: false,
+			wantPath:  "spec.replicas",
+		},
+		{
+			name:      "constraint violated",
+			data:      "spec:\n  template:\n    spec:\n      containers:\n        - ports:\n            - containerPort: 0\n",
+			wantValid: false,
+			wantPath:  "spec.template.spec.containers.0.ports.0.containerPort",
+		},
+		{
+			name:      "unknown field",
+			data:      "spec:\n  replica: 3\n",
+			wantValid: false,
+			wantPath:  "spec.replica",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.ValidatePatch(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatYAML,
+				Name:       "patch.yaml",
+			})
+			if err != nil {
+				t.Fatalf("ValidatePatch failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantPath != "" && (len(result.Errors) == 0 || result.Errors[0].Path != tt.wantPath) {
+				t.Errorf("errors = %v, want one at %q", result.Errors, tt.wantPath)
+			}
+		})
+	}
+}
+
+// TestValidatePatchOptions tests that partial documents go through the same
+// definition selection and optional checks as Validate
+func TestValidatePatchOptions(t *testing.T) {
+	schema := `#Config: {
+	kind!: "Deployment"
+	spec: replicas: int & >=0
+}
+#Service: {
+	kind!: "Service"
+	spec: port: int
+}`
+
+	tests := []struct {
+		name      string
+		opts      ValidationOptions
+		input     ValidationInput
+		wantValid bool
+		wantPath  string
+	}{
+		{
+			name:      "definition",
+			input:     ValidationInput{Data: []byte("spec:\n  port: 80\n"), Definition: "#Service"},
+			wantValid: true,
+		},
+		{
+			name:      "input path",
+			input:     ValidationInput{Data: []byte("app:\n  spec:\n    replicas: -1\n"), InputPath: "app"},
+			wantValid: false,
+			wantPath:  "app.spec.replicas",
+		},
+		{
+			name:      "discriminator",
+			opts:      ValidationOptions{DiscriminatorField: "kind", DiscriminatorMap: map[string]string{"Deployment": "#Config", "Service": "#Service"}},
+			input:     ValidationInput{Data: []byte("kind: Service\nspec:\n  port: http\n")},
+			wantValid: false,
+			wantPath:  "spec.port",
+		},
+		{
+			name:      "allowed extra fields",
+			opts:      ValidationOptions{AllowedExtraFields: []string{"x-note"}},
+			input:     ValidationInput{Data: []byte("spec:\n  x-note: scaled\n  replicas: 3\n")},
+			wantValid: true,
+		},
+		{
+			name: "post validate",
+			opts: ValidationOptions{PostValidate: func(unified cue.Value) []ValidationError {
+				return []ValidationError{{Path: "spec", Message: "frozen", Severity: SeverityError}}
+			}},
+			input:     ValidationInput{Data: []byte("spec:\n  replicas: 3\n")},
+			wantValid: false,
+			wantPath:  "spec",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, tt.opts)
+			tt.input.SourceType = SourceBytes
+			tt.input.Format = FormatYAML
+			tt.input.Name = "patch.yaml"
+			result, err := validator.ValidatePatch(tt.input)
+			if err != nil {
+				t.Fatalf("ValidatePatch failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantPath != "" && (len(result.Errors) == 0 || result.Errors[0].Path != tt.wantPath) {
+				t.Errorf("errors = %v, want one at %q", result.Errors, tt.wantPath)
+			}
+			if result.Duration <= 0 {
+				t.Errorf("Duration = %v, want it to be recorded", result.Duration)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cuebridge
+
+import (
+	"errors"
+	"strings"
+)
+
+// Precompile loads and compiles each schema and checks that it defines
+// definitionName, as NewValidator does, without keeping the validators.
+// It is meant for startup checks that should fail before serving traffic.
+//
+// Every schema is compiled even after a failure. The returned error joins
+// one error per failing schema, or is nil if all schemas compile. Each
+// schema's error starts on a new line with its path; further lines of a
+// multi-line error are indented, so every unindented line names a schema.
+func Precompile(schemaPaths []string, definitionName string) error {
+	var errs []error
+	for _, schemaPath := range schemaPaths {
+		if _, err := newValidator(schemaPath, definitionName, ValidationOptions{}); err != nil {
+			errs = append(errs, &schemaError{schemaPath: schemaPath, err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// schemaError is an error from loading one of several schemas
+type schemaError struct {
+	schemaPath string
+	err        error
+}
+
+// Error returns the schema path and error, with continuation lines indented
+func (e *schemaError) Error() string {
+	return e.schemaPath + ": " + strings.ReplaceAll(e.err.Error(), "\n", "\n\t")
+}
+
+// Unwrap returns the underlying error
+func (e *schemaError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,87 @@
+package cuebridge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPrecompile tests compiling several schemas up front
+func TestPrecompile(t *testing.T) {
+	schemaDir := t.TempDir()
+	files := map[string]string{
+		"good.cue":      "#Config: {name: string}\n",
+		"broken.cue":    "#Config: {name: \n",
+		"undefined.cue": "#Other: {name: string}\n",
+		"also-good.cue": "#Config: {id: int}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(schemaDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	path := func(name string) string { return filepath.Join(schemaDir, name) }
+
+	tests := []struct {
+		name        string
+		schemaPaths []string
+		wantErrs    []string
+	}{
+		{name: "no schemas"},
+		{name: "all compile", schemaPaths: []string{path("good.cue"), path("also-good.cue")}},
+		{
+			name:        "failures are combined",
+			schemaPaths: []string{path("broken.cue"), path("good.cue"), path("undefined.cue"), path("missing.cue")},
+			wantErrs: []string{
+				path("broken.cue") + ": ",
+				path("undefined.cue") + ": schema does not define #Config",
+				path("missing.cue") + ": reading schema file",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Precompile(tt.schemaPaths, "#Config")
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			lines := strings.Split(err.Error(), "\n")
+			for _, want := range tt.wantErrs {
+				found := false
+				for _, line := range lines {
+					found = found || strings.HasPrefix(line, want)
+				}
+				if !found {
+					t.Errorf("error %q has no line starting with %q", err, want)
+				}
+			}
+			if strings.Contains(err.Error(), path("good.cue")) {
+				t.Errorf("error mentions a schema that compiled: %v", err)
+			}
+		})
+	}
+}
+
+// TestPrecompileMultilineErrors tests that every unindented line of a
+// combined error names a schema
+func TestPrecompileMultilineErrors(t *testing.T) {
+	cause := errors.New("first problem\nsecond problem")
+	err := errors.Join(&schemaError{schemaPath: "a.cue", err: cause}, &schemaError{schemaPath: "b.cue", err: errors.New("broken")})
+
+	want := "a.cue: first problem\n\tsecond problem\nb.cue: broken"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("error does not wrap the schema's error")
+	}
+}
@@ -0,0 +1,124 @@
+package cuebridge
+
+import (
+	"fmt"
+	"regexp"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// undefinedFieldPattern matches the CUE error for a selector naming a field
+// its operand does not have (e.g., #Port.value when #Port has no value)
+var undefinedFieldPattern = regexp.MustCompile(`undefined field: (\S+)$`)
+
+// checkUnresolvedReferences returns an error naming the first reference in
+// a definition that cannot be resolved. References to undefined identifiers
+// already fail compilation, but a selector naming a field a closed struct
+// does not allow (e.g., #Port.value) only fails when evaluated, which would
+// blame every input. Selectors into open structs and pattern maps are
+// fine: input may supply the field. Fields, optional fields, pattern constraints, and
+// list elements are checked, so references reached through other
+// definitions are included.
+func checkUnresolvedReferences(definitionName string, configDef cue.Value) error {
+	e, path := findUndefinedField(configDef, nil)
+	if e == nil {
+		return nil
+	}
+
+	name := undefinedFieldPattern.FindStringSubmatch(e.Error())[1]
+	message := fmt.Sprintf("invalid schema: %s refers to undefined field %q", definitionName, name)
+	if len(path) > 0 {
+		message += fmt.Sprintf(" at %s", formatPath(path))
+	}
+	for _, pos := range errors.Positions(e) {
+		if pos.Line() > 0 {
+			message += fmt.Sprintf(" (%s)", pos)
+			break
+		}
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// findUndefinedField returns the first undefined field error in value at
+// path or the values it contains, and the path of the value it occurs in
+// (with "*" for list elements and pattern fields, as in FieldSpec.Path)
+func findUndefinedField(value cue.Value, path []string) (errors.Error, []string) {
+	if !value.Exists() {
+		return nil, nil
+	}
+	if err := value.Err(); err != nil {
+		// A selector into an open struct or a pattern map (e.g., labels.app
+		// with labels: [string]: string) is only incomplete until input
+		// supplies the field; other errors (e.g., a structural cycle) are
+		// left to validation
+		if !selectsDisallowedField(value) {
+			return nil, nil
+		}
+		for _, e := range errors.Errors(err) {
+			if undefinedFieldPattern.MatchString(e.Error()) {
+				return e, path
+			}
+		}
+		return nil, nil
+	}
+
+	type child struct {
+		label string
+		value cue.Value
+	}
+	var children []child
+	switch value.IncompleteKind() {
+	case cue.StructKind:
+		iter, err := value.Fields(cue.Optional(true))
+		if err != nil {
+			return nil, nil
+		}
+		for iter.Next() {
+			children = append(children, child{iter.Selector().Unquoted(), iter.Value()})
+		}
+		children = append(children, child{"*", value.LookupPath(cue.MakePath(cue.AnyString))})
+	case cue.ListKind:
+		if iter, err := value.List(); err == nil {
+			for iter.Next() {
+				children = append(children, child{iter.Selector().String(), iter.Value()})
+			}
+		}
+		children = append(children, child{"*", value.LookupPath(cue.MakePath(cue.AnyIndex))})
+	}
+
+	for _, c := range children {
+		childPath := append(path[:len(path):len(path)], c.label)
+		if e, errPath := findUndefinedField(c.value, childPath); e != nil {
+			return e, errPath
+		}
+	}
+	return nil, nil
+}
+
+// selectsDisallowedField reports whether value is, or is a conjunction
+// including, a selector whose operand is a closed struct that does not
+// allow the selected field
+func selectsDisallowedField(value cue.Value) bool {
+	if root, path := value.ReferencePath(); root.Exists() {
+		selectors := path.Selectors()
+		if len(selectors) == 0 {
+			return false
+		}
+		operand := root.LookupPath(cue.MakePath(selectors[:len(selectors)-1]...))
+		return operand.Exists() &&
+			operand.IncompleteKind() == cue.StructKind &&
+			!operand.Allows(selectors[len(selectors)-1])
+	}
+
+	op, args := value.Expr()
+	if op != cue.AndOp {
+		return false
+	}
+	for _, arg := range args {
+		if selectsDisallowedField(arg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestUnresolvedReferences tests rejecting schemas whose definition refers
+// to undefined names when the validator is created
+func TestUnresolvedReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{
+			name:    "undefined identifier",
+			schema:  "#Config: {port: intt}",
+			wantErr: `reference "intt" not found`,
+		},
+		{
+			name:    "undefined field of a definition",
+			schema:  "#Config: {port: #Port.value}\n#Port: {valeu: int}",
+			wantErr: `#Config refers to undefined field "value" at port (`,
+		},
+		{
+			name:    "through a list element and an optional field",
+			schema:  "#Config: {items?: [...#Item]}\n#Item: {limit: #Limits.max}\n#Limits: {min: 1}",
+			wantErr: `#Config refers to undefined field "max" at items.*.limit (`,
+		},
+		{
+			name:    "undefined field in a conjunction",
+			schema:  "#Config: {port: int & #Port.value}\n#Port: {valeu: int}",
+			wantErr: `#Config refers to undefined field "value" at port (`,
+		},
+		{
+			name:   "field of a pattern map",
+			schema: "#Config: {labels: [string]: string, app: labels.app}",
+		},
+		{
+			name:   "field of an open struct",
+			schema: "#Config: {env: {...}, port: env.PORT}",
+		},
+		{
+			name:   "recursive definition",
+			schema: "#Config: #Node\n#Node: {name: string, next?: #Node, children?: [...#Node]}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaPath := filepath.Join(t.TempDir(), "schema.cue")
+			if err := os.WriteFile(schemaPath, []byte(tt.schema), 0644); err != nil {
+				t.Fatalf("failed to write schema: %v", err)
+			}
+
+			_, err := NewValidator(schemaPath, "#Config")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewValidator failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewValidator() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
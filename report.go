@@ -0,0 +1,102 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Report collects validation results with their aggregate counts, total
+// duration, and schema provenance, computed once by NewReport.
+// The free functions (CountInvalid, TotalErrors, FormatResults, ...)
+// remain available for working with plain result slices.
+type Report struct {
+	// Results are the reported results, in order
+	Results []ValidationResult `json:"results"`
+	// Total is the number of results
+	Total int `json:"total"`
+	// Failed is the number of invalid results
+	Failed int `json:"failed"`
+	// Errors is the number of errors with SeverityError across all results
+	Errors int `json:"errors"`
+	// Warnings is the number of errors with SeverityWarning across all results
+	Warnings int `json:"warnings"`
+	// Duration is the sum of the results' durations
+	Duration time.Duration `json:"duration_ns"`
+	// SchemaPaths are the distinct schema paths of the results, in order of appearance
+	SchemaPaths []string `json:"schema_paths,omitempty"`
+	// Definitions are the distinct definitions of the results, in order of appearance
+	Definitions []string `json:"definitions,omitempty"`
+
+	text     string
+	jsonData []byte
+}
+
+// NewReport computes a report for results.
+// The report keeps a reference to results, which should not be modified.
+func NewReport(results []ValidationResult) *Report {
+	report := &Report{Results: results, Total: len(results)}
+
+	for _, result := range results {
+		if !result.Valid {
+			report.Failed++
+		}
+		for _, e := range result.Errors {
+			if e.Severity == SeverityWarning {
+				report.Warnings++
+			} else {
+				report.Errors++
+			}
+		}
+		report.Duration += result.Duration
+		if result.SchemaPath != "" && !slices.Contains(report.SchemaPaths, result.SchemaPath) {
+			report.SchemaPaths = append(report.SchemaPaths, result.SchemaPath)
+		}
+		if result.Definition != "" && !slices.Contains(report.Definitions, result.Definition) {
+			report.Definitions = append(report.Definitions, result.Definition)
+		}
+	}
+
+	return report
+}
+
+// HasFailures reports whether any result is invalid
+func (r *Report) HasFailures() bool {
+	return r.Failed > 0
+}
+
+// Text formats the results like FormatResults, followed by a summary line
+// (e.g., "3 inputs: 2 passed, 1 failed").
+// The output is computed on first use and cached.
+func (r *Report) Text() string {
+	if r.text == "" {
+		r.text = FormatResults(r.Results) +
+			fmt.Sprintf("%d inputs: %d passed, %d failed\n", r.Total, r.Total-r.Failed, r.Failed)
+	}
+	return r.text
+}
+
+// JSON encodes the report as an indented JSON object with the results
+// (formatted as by FormatResultsJSON) and the aggregate fields.
+// The output is computed on first use and cached.
+func (r *Report) JSON() ([]byte, error) {
+	if r.jsonData != nil {
+		return r.jsonData, nil
+	}
+
+	normalized := *r
+	normalized.Results = normalizeResults(r.Results)
+
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(normalized); err != nil {
+		return nil, fmt.Errorf("encoding report: %w", err)
+	}
+
+	r.jsonData = output.Bytes()
+	return r.jsonData, nil
+}
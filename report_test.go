@@ -0,0 +1,76 @@
+package cuebridge
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReport tests aggregate counts, provenance, and output of a report
+func TestReport(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "a.yaml", Valid: true, SchemaPath: "schema.cue", Definition: "#Config", Duration: time.Millisecond},
+		{Name: "b.yaml", Valid: true, SchemaPath: "schema.cue", Definition: "#Config", Duration: 2 * time.Millisecond, Errors: []ValidationError{
+			{Message: "field is deprecated", Severity: SeverityWarning},
+		}},
+		{Name: "c.yaml", Valid: false, SchemaPath: "schema.cue", Definition: "#Job", Errors: []ValidationError{
+			{Line: 3, Path: "replicas", Message: "invalid value 0"},
+			{Line: 4, Path: "name", Message: "incomplete value string"},
+		}},
+	}
+
+	report := NewReport(results)
+	if report.Total != 3 || report.Failed != 1 || report.Errors != 2 || report.Warnings != 1 {
+		t.Errorf("counts = %d/%d/%d/%d, want 3/1/2/1", report.Total, report.Failed, report.Errors, report.Warnings)
+	}
+	if report.Duration != 3*time.Millisecond {
+		t.Errorf("Duration = %v, want 3ms", report.Duration)
+	}
+	if strings.Join(report.SchemaPaths, ",") != "schema.cue" || strings.Join(report.Definitions, ",") != "#Config,#Job" {
+		t.Errorf("provenance = %v %v", report.SchemaPaths, report.Definitions)
+	}
+	if !report.HasFailures() {
+		t.Error("HasFailures = false, want true")
+	}
+	if NewReport(results[:2]).HasFailures() {
+		t.Error("HasFailures = true for valid results")
+	}
+
+	text := report.Text()
+	if !strings.HasPrefix(text, FormatResults(results)) || !strings.HasSuffix(text, "3 inputs: 2 passed, 1 failed\n") {
+		t.Errorf("unexpected text:\n%s", text)
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	var decoded struct {
+		Results []ValidationResult `json:"results"`
+		Failed  int                `json:"failed"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, data)
+	}
+	if len(decoded.Results) != 3 || decoded.Failed != 1 || decoded.Results[0].Errors == nil {
+		t.Errorf("unexpected JSON:\n%s", data)
+	}
+}
+
+// TestResultDuration tests that Validate records how long validation took
+func TestResultDuration(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "app"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("Duration = %v, want > 0", result.Duration)
+	}
+}
@@ -0,0 +1,108 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// LookupValue looks up a value in the compiled schema by CUE path
+// (e.g., "_version", "#Config.name").
+// Returns false if the path does not exist in the schema.
+func (v *Validator) LookupValue(path string) (cue.Value, bool) {
+	value := lookupPath(v.compiledSchema, path)
+	if !value.Exists() {
+		return cue.Value{}, false
+	}
+	return value, true
+}
+
+// LookupString looks up a concrete string in the compiled schema by CUE path.
+// Returns false if the path does not exist or is not a concrete string.
+func (v *Validator) LookupString(path string) (string, bool) {
+	value, ok := v.LookupValue(path)
+	if !ok {
+		return "", false
+	}
+	s, err := value.String()
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// lookupPath looks up a path in a value, also resolving hidden fields
+// (e.g., "_version") which cue.ParsePath does not accept
+func lookupPath(root cue.Value, path string) cue.Value {
+	parsed := cue.ParsePath(path)
+	if parsed.Err() == nil {
+		return root.LookupPath(parsed)
+	}
+
+	value := root
+	for _, elem := range strings.Split(path, ".") {
+		if strings.HasPrefix(elem, "_") {
+			value = lookupHiddenField(value, elem)
+			continue
+		}
+		sel := cue.ParsePath(elem)
+		if sel.Err() != nil {
+			return cue.Value{}
+		}
+		value = value.LookupPath(sel)
+	}
+	return value
+}
+
+// lookupHiddenField finds a hidden field by name regardless of its package
+func lookupHiddenField(value cue.Value, name string) cue.Value {
+	iter, err := value.Fields(cue.Hidden(true), cue.Definitions(true))
+	if err != nil {
+		return cue.Value{}
+	}
+	for iter.Next() {
+		if iter.Selector().String() == name {
+			return iter.Value()
+		}
+	}
+	return cue.Value{}
+}
+
+// lookupSchemaField returns the constraint a schema struct or list places
+// on the field or element sel of the data: the declared field, optional
+// or not, or else the pattern or list element constraint that applies.
+// The result does not exist if the schema has no constraint for sel.
+func lookupSchemaField(schema cue.Value, sel cue.Selector) cue.Value {
+	if value := schema.LookupPath(cue.MakePath(sel)); value.Exists() {
+		return value
+	}
+	switch sel.Type() {
+	case cue.StringLabel:
+		if value := schema.LookupPath(cue.MakePath(sel.Optional())); value.Exists() {
+			return value
+		}
+		return schema.LookupPath(cue.MakePath(cue.AnyString))
+	case cue.IndexLabel:
+		return schema.LookupPath(cue.MakePath(cue.AnyIndex))
+	default:
+		return cue.Value{}
+	}
+}
+
+// IsClosed reports whether the validator's definition is closed, i.e. it
+// rejects fields the schema does not declare. A definition with "..." or a
+// pattern constraint such as [string]: _ is open. Only the validator's own
+// definition is inspected, not one an input selects (see
+// ValidationInput.Definition and ValidationOptions.DiscriminatorField).
+// Returns an error if the definition is not a struct.
+func (v *Validator) IsClosed() (bool, error) {
+	configDef, err := v.definition()
+	if err != nil {
+		return false, err
+	}
+	if configDef.IncompleteKind() != cue.StructKind {
+		return false, fmt.Errorf("%s is not a struct", v.definitionName)
+	}
+	return !configDef.Allows(cue.AnyString), nil
+}
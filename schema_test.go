@@ -0,0 +1,290 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+// TestLookupString tests reading metadata from the schema
+func TestLookupString(t *testing.T) {
+	validator := newTestValidator(t, `package schemas
+
+_version: "1.2.0"
+#Config: {name: string, kind: "service"}
+`)
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "_version", want: "1.2.0", wantOK: true},
+		{path: "#Config.kind", want: "service", wantOK: true},
+		{path: "#Config.name", wantOK: false},
+		{path: "missing", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := validator.LookupString(tt.path)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("LookupString(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestNewValidatorFromValue tests validating against a programmatically built schema
+func TestNewValidatorFromValue(t *testing.T) {
+	ctx := cuecontext.New()
+	schema := ctx.CompileString(`#Config: {name: string}`)
+
+	validator, err := NewValidatorFromValue(schema, "#Config")
+	if err != nil {
+		t.Fatalf("NewValidatorFromValue failed: %v", err)
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "test"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("expected valid result, got %v", result.Errors)
+	}
+
+	if _, err := NewValidatorFromValue(schema, "#Missing"); err == nil {
+		t.Error("expected error for missing definition")
+	}
+	if _, err := NewValidatorFromValue(cue.Value{}, "#Config"); err == nil {
+		t.Error("expected error for zero value")
+	}
+}
+
+// TestSchemaImportsFromOtherDirectory tests resolving imports relative to the schema file
+func TestSchemaImportsFromOtherDirectory(t *testing.T) {
+	moduleDir := t.TempDir()
+	files := map[string]string{
+		"cue.mod/module.cue": "module: \"example.com/schemas\"\nlanguage: version: \"v0.9.0\"\n",
+		"common/common.cue":  "package common\n\n#Name: string & =~\"^[a-z]+$\"\n",
+		"schema.cue":         "package schemas\n\nimport \"example.com/schemas/common\"\n\n#Config: {name: common.#Name}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(moduleDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	// Run from an unrelated directory
+	t.Chdir(t.TempDir())
+
+	validator, err := NewValidator(filepath.Join(moduleDir, "schema.cue"), "#Config")
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	result, err := validator.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"name": "Invalid"}`),
+		Format:     FormatJSON,
+		Name:       "config.json",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("expected imported constraint to reject the input")
+	}
+}
+
+// TestSchemaStdlibImports tests schemas importing CUE's standard library,
+// both from a schema file and from the schema part of a self-contained file
+func TestSchemaStdlibImports(t *testing.T) {
+	schema := `import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+#Config: {
+	date:    time.Format("2006-01-02")
+	created: time.Time
+	name:    string & strings.MinRunes(2)
+	tag:     string & regexp.Valid
+}
+`
+	validator := newTestValidator(t, schema)
+
+	tests := []struct {
+		name      string
+		data      string
+		wantPaths []string
+		wantValid bool
+	}{
+		{
+			name:      "valid",
+			data:      `{"date": "2024-02-29", "created": "2024-02-29T10:00:00Z", "name": "app", "tag": "^v[0-9]+$"}`,
+			wantValid: true,
+		},
+		{
+			name:      "invalid date and time",
+			data:      `{"date": "2023-02-29", "created": "yesterday", "name": "app", "tag": "v1"}`,
+			wantPaths: []string{"created", "date"},
+		},
+		{
+			name:      "invalid strings and regexp",
+			data:      `{"date": "2024-01-01", "created": "2024-01-01T00:00:00Z", "name": "a", "tag": "("}`,
+			wantPaths: []string{"name", "tag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			slices.Sort(paths)
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+
+			// The same schema in a self-contained file
+			path := filepath.Join(t.TempDir(), "repro.cue")
+			content := schema + "--- data ---\n" + tt.data + "\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			selfContained, err := ValidateSelfContained(path)
+			if err != nil {
+				t.Fatalf("ValidateSelfContained failed: %v", err)
+			}
+			if selfContained.Valid != tt.wantValid {
+				t.Errorf("self-contained Valid = %v, want %v (errors: %v)", selfContained.Valid, tt.wantValid, selfContained.Errors)
+			}
+		})
+	}
+}
+
+// TestSchemaPackage tests loading definitions from named packages
+func TestSchemaPackage(t *testing.T) {
+	schemaDir := t.TempDir()
+	files := map[string]string{
+		"schemas.cue": "package schemas\n\n#Config: {name: string}\n",
+		"more.cue":    "package schemas\n\n#Extra: {id: int}\n",
+		"other.cue":   "package other\n\n#Config: {id: int}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(schemaDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schemaPath string
+		pkg        string
+		definition string
+		data       string
+		wantValid  bool
+		wantErr    string
+	}{
+		{name: "packaged file", schemaPath: "schemas.cue", definition: "#Config", data: `{"name": "a"}`, wantValid: true},
+		{name: "packaged file with package", schemaPath: "schemas.cue", pkg: "schemas", definition: "#Config", data: `{"name": "a"}`, wantValid: true},
+		{name: "file in another package", schemaPath: "other.cue", pkg: "schemas", wantErr: `is in package "other", not "schemas"`},
+		{name: "directory package", schemaPath: ".", pkg: "schemas", definition: "#Extra", data: `{"id": 1}`, wantValid: true},
+		{name: "directory package rejects", schemaPath: ".", pkg: "other", definition: "#Config", data: `{"name": "a"}`},
+		{name: "directory with several packages", schemaPath: ".", wantErr: "found packages"},
+		{name: "directory without package", schemaPath: ".", pkg: "missing", wantErr: "loading schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := NewValidatorWithOptions(filepath.Join(schemaDir, tt.schemaPath), "#Config", ValidationOptions{Package: tt.pkg})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewValidatorWithOptions failed: %v", err)
+			}
+
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+				Definition: tt.definition,
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+		})
+	}
+}
+
+// TestIsClosed tests reporting whether the definition rejects extra fields
+func TestIsClosed(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "closed", schema: `#Config: {name: string}`, want: true},
+		{name: "ellipsis", schema: `#Config: {name: string, ...}`, want: false},
+		{name: "pattern", schema: `#Config: {[string]: int}`, want: false},
+		{name: "not a struct", schema: `#Config: int`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidator(t, tt.schema)
+			got, err := validator.IsClosed()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IsClosed failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsClosed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package cuebridge
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"gopkg.in/yaml.v3"
+)
+
+// SelfContainedDelimiter is the line that separates the CUE schema from the
+// data in a self-contained file (see ValidateSelfContained)
+const SelfContainedDelimiter = "--- data ---"
+
+// ValidateSelfContained validates a file that embeds both a CUE schema and
+// the data to validate, e.g. a shareable repro case:
+//
+//	#Config: {port: int & >0}
+//	--- data ---
+//	port: 8080
+//
+// Everything before the first SelfContainedDelimiter line is compiled as
+// the schema; everything after it is the data, whose format (JSON or YAML)
+// is detected from its content. Line numbers in the result refer to lines
+// of the whole file and point at the data where possible.
+// The schema part can import CUE's standard library (e.g., "time") but
+// no other packages.
+//
+// The data is checked against the schema's only definition or, if it has
+// several (e.g., helpers of the one under test), against #Config, so that
+// the file needs nothing besides itself to be validated.
+//
+// Returns an error if the file cannot be read, has no delimiter line,
+// its schema part fails to compile, or no definition can be chosen.
+func ValidateSelfContained(path string) (ValidationResult, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("reading file: %w", err)
+	}
+
+	schemaPart, dataPart, dataLine, ok := splitAtDelimiter(content, SelfContainedDelimiter)
+	if !ok {
+		return ValidationResult{}, fmt.Errorf("%s: missing %q line separating schema and data", path, SelfContainedDelimiter)
+	}
+
+	schema := cuecontext.New().CompileBytes(schemaPart, cue.Filename(path))
+	if schema.Err() != nil {
+		return ValidationResult{}, fmt.Errorf("compiling schema: %w", schema.Err())
+	}
+	definitionName, err := embeddedDefinition(schema, "")
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	validator, err := newValidatorFromValue(schema, path, definitionName, ValidationOptions{
+		PreferInputPositions: true,
+	})
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	return validator.validateEmbeddedData(path, dataPart, dataLine)
+}
+
+// ValidateFrontmatter validates the body of a file whose YAML frontmatter
+// names the schema, as used by documentation and content tooling:
+//
+//	---
+//	schema_path: schemas/page.cue
+//	---
+//	title: Getting started
+//
+// The frontmatter sets exactly one of:
+//   - schema_path: a CUE schema file, relative to the file's directory
+//   - schema: the CUE schema itself, e.g. as a block scalar (schema: |)
+//
+// It may also set definition (e.g., definition: "#Page") to name the
+// definition the body is checked against. Without it, the schema's only
+// definition is used, or #Config if the schema has several.
+//
+// The body's format (JSON or YAML) is detected from its content.
+// Line numbers in the result refer to lines of the whole file.
+//
+// Returns an error if the file cannot be read, has no frontmatter, or the
+// frontmatter does not set exactly one usable schema and definition.
+func ValidateFrontmatter(path string) (ValidationResult, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("reading file: %w", err)
+	}
+
+	firstLine, rest, _, ok := splitAtDelimiter(content, frontmatterDelimiter)
+	if !ok || len(bytes.TrimSpace(firstLine)) > 0 {
+		return ValidationResult{}, fmt.Errorf("%s: no frontmatter: the file must start with a %q line", path, frontmatterDelimiter)
+	}
+	frontmatterPart, body, bodyLine, ok := splitAtDelimiter(rest, frontmatterDelimiter)
+	if !ok {
+		return ValidationResult{}, fmt.Errorf("%s: frontmatter is not closed by a %q line", path, frontmatterDelimiter)
+	}
+
+	var frontmatter struct {
+		Schema     string `yaml:"schema"`
+		SchemaPath string `yaml:"schema_path"`
+		Definition string `yaml:"definition"`
+	}
+	if err := yaml.Unmarshal(frontmatterPart, &frontmatter); err != nil {
+		return ValidationResult{}, fmt.Errorf("parsing frontmatter: %w", err)
+	}
+
+	ctx := cuecontext.New()
+	var schema cue.Value
+	var schemaPath string
+	switch {
+	case frontmatter.Schema != "" && frontmatter.SchemaPath != "":
+		return ValidationResult{}, fmt.Errorf("%s: frontmatter sets both schema and schema_path", path)
+	case frontmatter.SchemaPath != "":
+		schemaPath = frontmatter.SchemaPath
+		if !filepath.IsAbs(schemaPath) {
+			schemaPath = filepath.Join(filepath.Dir(path), schemaPath)
+		}
+		if schema, err = compileSchemaFile(ctx, schemaPath, ValidationOptions{}); err != nil {
+			return ValidationResult{}, err
+		}
+	case frontmatter.Schema != "":
+		schemaPath = path
+		schema = ctx.CompileString(frontmatter.Schema, cue.Filename(path))
+		if schema.Err() != nil {
+			return ValidationResult{}, fmt.Errorf("compiling schema: %w", schema.Err())
+		}
+	default:
+		return ValidationResult{}, fmt.Errorf("%s: frontmatter sets neither schema nor schema_path", path)
+	}
+	definitionName, err := embeddedDefinition(schema, frontmatter.Definition)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	validator, err := newValidatorFromValue(schema, schemaPath, definitionName, ValidationOptions{
+		PreferInputPositions: true,
+	})
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	// The body starts after the opening line and the frontmatter
+	return validator.validateEmbeddedData(path, body, bodyLine+1)
+}
+
+// frontmatterDelimiter is the line that opens and closes frontmatter
+const frontmatterDelimiter = "---"
+
+// embeddedDefinition returns the definition embedded data is checked
+// against: named if set, otherwise the schema's only definition, or
+// #Config if it has several
+func embeddedDefinition(schema cue.Value, named string) (string, error) {
+	if named != "" {
+		return named, nil
+	}
+
+	var definitions []string
+	iter, err := schema.Fields(cue.Definitions(true))
+	if err != nil {
+		return "", fmt.Errorf("reading schema definitions: %w", err)
+	}
+	for iter.Next() {
+		if iter.Selector().IsDefinition() {
+			definitions = append(definitions, iter.Selector().String())
+		}
+	}
+
+	switch {
+	case len(definitions) == 1:
+		return definitions[0], nil
+	case slices.Contains(definitions, "#Config"):
+		return "#Config", nil
+	case len(definitions) == 0:
+		return "", fmt.Errorf("schema defines no definition")
+	default:
+		return "", fmt.Errorf("schema defines %s but no #Config", strings.Join(definitions, ", "))
+	}
+}
+
+// validateEmbeddedData validates data embedded in the file at path,
+// starting at the 1-based line dataLine. The result is named by the
+// file's base name.
+func (v *Validator) validateEmbeddedData(path string, dataPart []byte, dataLine int) (ValidationResult, error) {
+	// Pad the data so that its line numbers match the whole file
+	data := append(bytes.Repeat([]byte("\n"), dataLine-1), dataPart...)
+	// The data is parsed under its own file name: an inline schema is
+	// compiled as path, which may equal the base name, and positions in
+	// the schema must not be taken for positions in the data
+	result, err := v.validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       data,
+		Format:     formatFromContent(dataPart),
+		Name:       path + "#data",
+	})
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	result.Name = filepath.Base(path)
+	return result, nil
+}
+
+// splitAtDelimiter splits content at the first line equal to delimiter,
+// returning the parts before and after it and the 1-based line number
+// where the part after it starts
+func splitAtDelimiter(content []byte, delimiter string) ([]byte, []byte, int, bool) {
+	offset := 0
+	for i, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if string(bytes.TrimRight(line, "\r\n")) == delimiter {
+			return content[:offset], content[offset+len(line):], i + 2, true
+		}
+		offset += len(line)
+	}
+	return nil, nil, 0, false
+}
@@ -0,0 +1,203 @@
+package cuebridge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidateSelfContained tests validating a file holding schema and data
+func TestValidateSelfContained(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantValid bool
+		wantLine  int
+		wantErr   bool
+	}{
+		{
+			name:      "valid yaml",
+			content:   "#Config: {port: int & >0}\n--- data ---\nport: 8080\n",
+			wantValid: true,
+		},
+		{
+			name:      "valid json",
+			content:   "#Config: {port: int & >0}\n--- data ---\n{\"port\": 8080}\n",
+			wantValid: true,
+		},
+		{
+			name:      "invalid data reports file line",
+			content:   "#Config: {\n\tname: string\n\tport: int & >0\n}\n--- data ---\nname: app\nport: 0\n",
+			wantValid: false,
+			wantLine:  7,
+		},
+		{
+			name:      "only definition",
+			content:   "#Server: {port: int & >0}\n--- data ---\nport: 0\n",
+			wantValid: false,
+			wantLine:  3,
+		},
+		{
+			name:      "helper definitions",
+			content:   "#Port: int & >0\n#Config: {port: #Port}\n--- data ---\nport: 8080\n",
+			wantValid: true,
+		},
+		{
+			name:    "several definitions without #Config",
+			content: "#Port: int & >0\n#Server: {port: #Port}\n--- data ---\nport: 8080\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing delimiter",
+			content: "#Config: {port: int}\nport: 8080\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid schema",
+			content: "#Config: {port: int\n--- data ---\nport: 8080\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "repro.cue")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			result, err := ValidateSelfContained(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateSelfContained failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if result.Name != "repro.cue" {
+				t.Errorf("Name = %q, want %q", result.Name, "repro.cue")
+			}
+			if tt.wantLine > 0 {
+				found := false
+				for _, e := range result.Errors {
+					if e.Line == tt.wantLine {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("no error on line %d: %v", tt.wantLine, result.Errors)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateSelfContainedRelativePath tests that schema positions are not
+// taken for data positions when the file is named by its base name
+func TestValidateSelfContainedRelativePath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	content := "#Config: {\n\tport: int & >0\n}\n--- data ---\nport: 0\n"
+	if err := os.WriteFile("repro.cue", []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	result, err := ValidateSelfContained("repro.cue")
+	if err != nil {
+		t.Fatalf("ValidateSelfContained failed: %v", err)
+	}
+	if result.Valid || result.Name != "repro.cue" {
+		t.Fatalf("got Valid = %v, Name = %q, want an invalid result named repro.cue", result.Valid, result.Name)
+	}
+	if e := result.Errors[0]; e.Line != 5 {
+		t.Errorf("got line %d, want line 5 (errors: %v)", e.Line, result.Errors)
+	}
+}
+
+// TestValidateFrontmatter tests validating a body against the schema named in its frontmatter
+func TestValidateFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "schemas"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	schema := "#Page: {title: string, weight?: int & >=0}\n"
+	if err := os.WriteFile(filepath.Join(dir, "schemas", "page.cue"), []byte(schema), 0644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantValid bool
+		wantLine  int
+		wantErr   bool
+	}{
+		{
+			name:      "schema path",
+			content:   "---\nschema_path: schemas/page.cue\n---\ntitle: Getting started\nweight: 1\n",
+			wantValid: true,
+		},
+		{
+			name:      "inline schema reports file line",
+			content:   "---\nschema: |\n  #Page: {title: string, weight?: int & >=0}\n---\ntitle: Getting started\nweight: -1\n",
+			wantValid: false,
+			wantLine:  6,
+		},
+		{
+			name:      "named definition",
+			content:   "---\ndefinition: \"#Strict\"\nschema: |\n  #Page: {title: string}\n  #Strict: close({title: string & =~\"^[A-Z]\"})\n---\ntitle: getting started\n",
+			wantValid: false,
+			wantLine:  7,
+		},
+		{
+			name:    "several definitions without #Config",
+			content: "---\nschema: |\n  #Page: {title: string}\n  #Post: {title: string}\n---\ntitle: Getting started\n",
+			wantErr: true,
+		},
+		{
+			name:    "no frontmatter",
+			content: "title: Getting started\n",
+			wantErr: true,
+		},
+		{
+			name:    "no schema",
+			content: "---\nauthor: me\n---\ntitle: Getting started\n",
+			wantErr: true,
+		},
+		{
+			name:    "both schema keys",
+			content: "---\nschema: \"#Page: _\"\nschema_path: schemas/page.cue\n---\ntitle: Getting started\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "page.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			result, err := ValidateFrontmatter(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateFrontmatter failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantLine > 0 && result.Errors[0].Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d (errors: %v)", result.Errors[0].Line, tt.wantLine, result.Errors)
+			}
+		})
+	}
+}
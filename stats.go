@@ -0,0 +1,62 @@
+package cuebridge
+
+import (
+	"sync/atomic"
+)
+
+// Stats counts the results of the streaming validators as they are
+// produced, e.g. to render a progress bar while a large batch runs. Set
+// ValidationOptions.Stats to have ValidateDirStream and ValidateJSONStream
+// update it; read it from any goroutine with Snapshot.
+//
+// The zero value is ready to use. Updates are lock-free.
+type Stats struct {
+	current atomic.Pointer[StatsSnapshot]
+}
+
+// StatsSnapshot is a consistent copy of Stats at one point in time:
+// Processed is always Valid plus Invalid, and Errors covers exactly
+// the processed results.
+type StatsSnapshot struct {
+	// Processed is the number of results produced so far
+	Processed int64
+	// Valid is the number of results that passed validation
+	Valid int64
+	// Invalid is the number of results that failed validation
+	Invalid int64
+	// Errors is the number of individual errors in those results,
+	// including warnings (see TotalErrors)
+	Errors int64
+}
+
+// Snapshot returns the current counts
+func (s *Stats) Snapshot() StatsSnapshot {
+	if current := s.current.Load(); current != nil {
+		return *current
+	}
+	return StatsSnapshot{}
+}
+
+// record adds result to the counts. A nil Stats records nothing.
+func (s *Stats) record(result ValidationResult) {
+	if s == nil {
+		return
+	}
+	for {
+		old := s.current.Load()
+		next := StatsSnapshot{}
+		if old != nil {
+			next = *old
+		}
+		next.Processed++
+		if result.Valid {
+			next.Valid++
+		} else {
+			next.Invalid++
+		}
+		next.Errors += int64(len(result.Errors))
+		if s.current.CompareAndSwap(old, &next) {
+			return
+		}
+	}
+}
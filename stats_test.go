@@ -0,0 +1,87 @@
+package cuebridge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestStats tests counting results of the streaming validators
+func TestStats(t *testing.T) {
+	schema := `#Config: {name: string, port: int}`
+
+	t.Run("ValidateDirStream", func(t *testing.T) {
+		stats := &Stats{}
+		validator := newTestValidatorWithOptions(t, schema, ValidationOptions{Stats: stats})
+
+		tmpDir := t.TempDir()
+		for i := range 4 {
+			content := fmt.Sprintf("name: app%d\nport: 80\n", i)
+			if i%2 == 1 {
+				content = "name: 1\nport: x\n"
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("app%d.yaml", i)), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+		}
+
+		received := int64(0)
+		for range validator.ValidateDirStream(context.Background(), tmpDir) {
+			received++
+			if got := stats.Snapshot().Processed; got < received {
+				t.Errorf("Processed = %d after receiving %d results", got, received)
+			}
+		}
+
+		want := StatsSnapshot{Processed: 4, Valid: 2, Invalid: 2, Errors: 4}
+		if got := stats.Snapshot(); got != want {
+			t.Errorf("Snapshot() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("ValidateJSONStream", func(t *testing.T) {
+		stats := &Stats{}
+		validator := newTestValidatorWithOptions(t, schema, ValidationOptions{Stats: stats})
+
+		content := `{"name": "a", "port": 1} {"name": "b"} {"name": `
+		for range validator.ValidateJSONStream(ValidationInput{
+			SourceType: SourceReader,
+			Reader:     strings.NewReader(content),
+			Name:       "log",
+		}) {
+		}
+
+		want := StatsSnapshot{Processed: 3, Valid: 1, Invalid: 2, Errors: 2}
+		if got := stats.Snapshot(); got != want {
+			t.Errorf("Snapshot() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		var stats Stats
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					stats.record(ValidationResult{Valid: i%2 == 0, Errors: make([]ValidationError, i%2)})
+					snapshot := stats.Snapshot()
+					if snapshot.Processed != snapshot.Valid+snapshot.Invalid {
+						t.Errorf("inconsistent snapshot %+v", snapshot)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		want := StatsSnapshot{Processed: 800, Valid: 400, Invalid: 400, Errors: 400}
+		if got := stats.Snapshot(); got != want {
+			t.Errorf("Snapshot() = %+v, want %+v", got, want)
+		}
+	})
+}
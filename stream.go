@@ -0,0 +1,195 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"cuelang.org/go/cue"
+	"gopkg.in/yaml.v3"
+)
+
+// StreamResult contains the results of validating a multi-document stream.
+type StreamResult struct {
+	// Results contains one result per document, named "<name>[<index>]"
+	Results []ValidationResult
+	// DocumentCount is the number of documents found in the stream
+	DocumentCount int
+}
+
+// ValidateStream validates each document of a multi-document YAML stream
+// (documents separated by "---") against the schema.
+// JSON input, and YAML input with a single document, count as one document.
+// Each document is checked like an input of its own: input.Definition and
+// input.InputPath apply to every document, and with a discriminator field
+// each document selects its definition by its own value.
+//
+// If the stream cannot be parsed, Results holds the single parse failure
+// and DocumentCount is 0.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateStream(input ValidationInput) (StreamResult, error) {
+	parsed, failed, err := v.parseInput(input)
+	if err != nil {
+		return StreamResult{}, err
+	}
+	if failed != nil {
+		return StreamResult{Results: []ValidationResult{*failed}}, nil
+	}
+
+	documents, err := streamDocuments(parsed)
+	if err != nil {
+		return StreamResult{}, err
+	}
+
+	stream := StreamResult{DocumentCount: len(documents)}
+	for _, document := range documents {
+		// Each document selects its own definition (e.g., by its kind)
+		definitionName, configDef, failed, err := v.inputDefinition(input, &document)
+		if err != nil {
+			return StreamResult{}, err
+		}
+		if failed != nil {
+			failed.Name = document.source.name
+			stream.Results = append(stream.Results, *failed)
+			continue
+		}
+		stream.Results = append(stream.Results, v.checkInput(definitionName, configDef, document))
+	}
+	return stream, nil
+}
+
+// ValidateStreamFirstValid validates the documents of a multi-document YAML
+// stream in order (see ValidateStream) and returns the result for the first
+// document that satisfies the schema, along with its index.
+//
+// If no document is valid, the returned result is invalid, named after the
+// input, and contains the errors of every document; the index is -1.
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateStreamFirstValid(input ValidationInput) (ValidationResult, int, error) {
+	stream, err := v.ValidateStream(input)
+	if err != nil {
+		return ValidationResult{}, -1, err
+	}
+	if stream.DocumentCount == 0 {
+		return stream.Results[0], -1, nil
+	}
+
+	combined := v.withProvenance(ValidationResult{Name: input.Name, Valid: false})
+	for i, result := range stream.Results {
+		if result.Valid {
+			return result, i, nil
+		}
+		combined.Errors = append(combined.Errors, result.Errors...)
+	}
+	return combined, -1, nil
+}
+
+// ValidateJSONStream validates a stream of concatenated JSON values
+// (e.g., back-to-back objects in a log), yielding a result for each value
+// as soon as it is decoded, named "<name>[<index>]". The input's Format
+// is ignored; its Definition, InputPath, and Proto3JSON apply to every
+// value. Like Validate, a byte order mark is stripped and UTF-16 input is
+// transcoded as it is read. Line numbers are relative to each value.
+//
+// A syntax error yields a final invalid result for the value that could
+// not be decoded. Errors reading the input are yielded as errors, after
+// which iteration stops.
+func (v *Validator) ValidateJSONStream(input ValidationInput) iter.Seq2[ValidationResult, error] {
+	return func(yield func(ValidationResult, error) bool) {
+		reader, err := v.openInput(input)
+		if err != nil {
+			yield(ValidationResult{}, err)
+			return
+		}
+		defer reader.Close()
+
+		decoder := json.NewDecoder(reader)
+		for i := 0; ; i++ {
+			name := fmt.Sprintf("%s[%d]", input.Name, i)
+
+			var raw json.RawMessage
+			err := decoder.Decode(&raw)
+			if err == io.EOF {
+				return
+			}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				result := v.withProvenance(createParseErrorResult(name, err))
+				v.options.Stats.record(result)
+				yield(result, nil)
+				return
+			}
+			if err != nil {
+				yield(ValidationResult{}, fmt.Errorf("reading input: %w", err))
+				return
+			}
+
+			result, err := v.validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       raw,
+				Format:     FormatJSON,
+				Name:       name,
+				Definition: input.Definition,
+				InputPath:  input.InputPath,
+				Proto3JSON: input.Proto3JSON,
+			})
+			if err == nil {
+				v.options.Stats.record(result)
+			}
+			if !yield(result, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamDocuments splits parsed input into one parsed input per document
+func streamDocuments(parsed parsedInput) ([]parsedInput, error) {
+	if parsed.format != FormatYAML || countYAMLDocuments(parsed.source.data) <= 1 {
+		document := parsed
+		document.source = documentSource(parsed.source, 0, parsed.value)
+		return []parsedInput{document}, nil
+	}
+
+	// The YAML extractor represents a multi-document stream as a list of documents
+	iter, err := parsed.value.List()
+	if err != nil {
+		return nil, fmt.Errorf("reading YAML documents: %w", err)
+	}
+
+	var documents []parsedInput
+	for i := 0; iter.Next(); i++ {
+		documents = append(documents, parsedInput{
+			value:  iter.Value(),
+			format: parsed.format,
+			source: documentSource(parsed.source, i, iter.Value()),
+		})
+	}
+	return documents, nil
+}
+
+// documentSource returns the source context for the document at index,
+// named "<name>[<index>]". Positions still refer to the whole stream.
+func documentSource(source *sourceContext, index int, value cue.Value) *sourceContext {
+	document := *source
+	document.value = value
+	document.name = fmt.Sprintf("%s[%d]", source.name, index)
+	return &document
+}
+
+// countYAMLDocuments returns the number of documents in a YAML stream
+func countYAMLDocuments(data []byte) int {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	count := 0
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			// io.EOF ends the stream; syntax errors were already reported by the parser
+			return count
+		}
+		count++
+	}
+}
@@ -0,0 +1,193 @@
+package cuebridge
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestValidateStream tests per-document results and the document count
+func TestValidateStream(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {name: string}`)
+
+	tests := []struct {
+		name      string
+		content   string
+		format    DataFormat
+		wantValid []bool
+	}{
+		{
+			name:      "three documents",
+			content:   "name: a\n---\nwrong: b\n---\nname: c\n",
+			format:    FormatYAML,
+			wantValid: []bool{true, false, true},
+		},
+		{
+			name:      "single document with leading separator",
+			content:   "---\nname: a\n",
+			format:    FormatYAML,
+			wantValid: []bool{true},
+		},
+		{
+			name:      "json",
+			content:   `{"name": "a"}`,
+			format:    FormatJSON,
+			wantValid: []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := validator.ValidateStream(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.content),
+				Format:     tt.format,
+				Name:       "stream",
+			})
+			if err != nil {
+				t.Fatalf("ValidateStream failed: %v", err)
+			}
+
+			if stream.DocumentCount != len(tt.wantValid) {
+				t.Fatalf("DocumentCount = %d, want %d", stream.DocumentCount, len(tt.wantValid))
+			}
+			for i, result := range stream.Results {
+				if result.Valid != tt.wantValid[i] {
+					t.Errorf("%s: Valid = %v, want %v", result.Name, result.Valid, tt.wantValid[i])
+				}
+			}
+		})
+	}
+}
+
+// TestValidateJSONStream tests validating concatenated JSON values
+func TestValidateJSONStream(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {level: "info" | "error", msg: string}
+#Audit: {user: string, action: string}`)
+
+	tests := []struct {
+		name       string
+		content    string
+		definition string
+		wantNames  []string
+		wantValid  []bool
+	}{
+		{
+			name:      "back-to-back objects",
+			content:   `{"level": "info", "msg": "a"}{"level": "debug", "msg": "b"}` + "\n" + `{"level": "error", "msg": "c"}` + "\n\n",
+			wantNames: []string{"log[0]", "log[1]", "log[2]"},
+			wantValid: []bool{true, false, true},
+		},
+		{
+			name:    "empty stream",
+			content: " \n",
+		},
+		{
+			name:       "definition applies to every value",
+			content:    `{"user": "a", "action": "login"}{"level": "info", "msg": "a"}`,
+			definition: "#Audit",
+			wantNames:  []string{"log[0]", "log[1]"},
+			wantValid:  []bool{true, false},
+		},
+		{
+			name:      "utf-8 byte order mark",
+			content:   "\uFEFF" + `{"level": "info", "msg": "a"}{"level": "error", "msg": "b"}`,
+			wantNames: []string{"log[0]", "log[1]"},
+			wantValid: []bool{true, true},
+		},
+		{
+			name:      "utf-16le",
+			content:   string(encodeUTF16LE(`{"level": "info", "msg": "😀"}{"level": "debug", "msg": "b"}`)),
+			wantNames: []string{"log[0]", "log[1]"},
+			wantValid: []bool{true, false},
+		},
+		{
+			name:      "syntax error ends the stream",
+			content:   `{"level": "info", "msg": "a"} {"level": `,
+			wantNames: []string{"log[0]", "log[1]"},
+			wantValid: []bool{true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			var valid []bool
+			for result, err := range validator.ValidateJSONStream(ValidationInput{
+				SourceType: SourceReader,
+				Reader:     strings.NewReader(tt.content),
+				Name:       "log",
+				Definition: tt.definition,
+			}) {
+				if err != nil {
+					t.Fatalf("ValidateJSONStream failed: %v", err)
+				}
+				names = append(names, result.Name)
+				valid = append(valid, result.Valid)
+			}
+			if !slices.Equal(names, tt.wantNames) || !slices.Equal(valid, tt.wantValid) {
+				t.Errorf("got %v %v, want %v %v", names, valid, tt.wantNames, tt.wantValid)
+			}
+		})
+	}
+}
+
+// TestValidateStreamFirstValid tests selecting the first valid document
+func TestValidateStreamFirstValid(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {kind: "Config", name: string}`)
+
+	tests := []struct {
+		name       string
+		content    string
+		wantIndex  int
+		wantName   string
+		wantErrors int
+	}{
+		{
+			name:      "second document matches",
+			content:   "kind: Service\nname: a\n---\nkind: Config\nname: b\n---\nkind: Config\nname: c\n",
+			wantIndex: 1,
+			wantName:  "stream[1]",
+		},
+		{
+			name:       "no document matches",
+			content:    "kind: Service\n---\nkind: Secret\n",
+			wantIndex:  -1,
+			wantName:   "stream",
+			wantErrors: 2,
+		},
+		{
+			name:       "parse failure",
+			content:    "kind: [\n",
+			wantIndex:  -1,
+			wantName:   "stream",
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, index, err := validator.ValidateStreamFirstValid(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.content),
+				Format:     FormatYAML,
+				Name:       "stream",
+			})
+			if err != nil {
+				t.Fatalf("ValidateStreamFirstValid failed: %v", err)
+			}
+			if index != tt.wantIndex {
+				t.Errorf("index = %d, want %d", index, tt.wantIndex)
+			}
+			if result.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", result.Name, tt.wantName)
+			}
+			if result.Valid != (tt.wantIndex >= 0) {
+				t.Errorf("Valid = %v, want %v", result.Valid, tt.wantIndex >= 0)
+			}
+			if tt.wantErrors > 0 && len(result.Errors) < tt.wantErrors {
+				t.Errorf("got %d errors, want at least %d: %v", len(result.Errors), tt.wantErrors, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cuebridge
+
+import (
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// ValidateStructural validates input like Validate, but reports only
+// conflicts and type errors: required fields may be missing and values
+// may be left non-concrete (e.g., a field only constrained to string).
+// This suits linting partial configs that are completed elsewhere.
+//
+// Otherwise the input is checked like in Validate: input.Definition,
+// input.InputPath, the discriminator field, and the optional checks of the
+// validator options all apply, and the outcome is logged. Results are not
+// cached.
+//
+// Returns an error only if the validation process itself fails.
+func (v *Validator) ValidateStructural(input ValidationInput) (ValidationResult, error) {
+	return v.validateWith(input, v.validateStructural)
+}
+
+// validateStructural reads, parses, and checks a single input without
+// requiring it to be complete
+func (v *Validator) validateStructural(input ValidationInput) (ValidationResult, error) {
+	parsed, failed, err := v.parseInput(input)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if failed != nil {
+		return *failed, nil
+	}
+
+	definitionName, configDef, failed, err := v.inputDefinition(input, &parsed)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if failed != nil {
+		return *failed, nil
+	}
+
+	// Absent and incomplete fields are accepted while conflicts and
+	// disallowed fields are still reported
+	parsed.structural = true
+	return v.checkInput(definitionName, configDef, parsed), nil
+}
+
+// validateStructure validates a unified value without requiring it to be
+// concrete, dropping any remaining errors about incomplete values
+func validateStructure(unified cue.Value) error {
+	var remaining errors.Error
+	for _, e := range errors.Errors(unified.Validate(cue.Concrete(false))) {
+		if !isIncompleteError(e) {
+			remaining = errors.Append(remaining, e)
+		}
+	}
+	if remaining == nil {
+		return nil
+	}
+	return remaining
+}
+
+// isIncompleteError reports whether e is about a value that is not yet
+// concrete rather than a conflict
+func isIncompleteError(e errors.Error) bool {
+	message := e.Error()
+	return strings.Contains(message, "incomplete value") || strings.Contains(message, "non-concrete value")
+}
@@ -0,0 +1,98 @@
+package cuebridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+// TestValidateStructural tests reporting only conflicts and type errors
+func TestValidateStructural(t *testing.T) {
+	validator := newTestValidator(t, `#Config: {
+	name:     string
+	port:     int & >0
+	mode:     "fast" | "safe"
+	replicas: int
+	total:    replicas * 2
+}`)
+
+	tests := []struct {
+		name      string
+		data      string
+		wantValid bool
+		wantPaths []string
+	}{
+		{name: "empty input", data: `{}`, wantValid: true},
+		{name: "partial input", data: `{"name": "app", "replicas": 2}`, wantValid: true},
+		{name: "type error", data: `{"port": "80"}`, wantPaths: []string{"port"}},
+		{name: "bound violation", data: `{"port": 0, "mode": "slow"}`, wantPaths: []string{"port", "mode"}},
+		{name: "closed struct", data: `{"extra": true}`, wantPaths: []string{"extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+			}
+			result, err := validator.ValidateStructural(input)
+			if err != nil {
+				t.Fatalf("ValidateStructural failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v: %v", result.Valid, tt.wantValid, result.Errors)
+			}
+			for _, path := range tt.wantPaths {
+				found := false
+				for _, e := range result.Errors {
+					found = found || e.Path == path
+				}
+				if !found {
+					t.Errorf("expected an error at %s, got %v", path, result.Errors)
+				}
+			}
+
+			// Validate still requires a complete config
+			full, err := validator.Validate(input)
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if full.Valid {
+				t.Error("expected Validate to reject the incomplete input")
+			}
+		})
+	}
+}
+
+// TestValidateStructuralLogging tests that structural checks are timed and
+// logged like Validate
+func TestValidateStructuralLogging(t *testing.T) {
+	var output bytes.Buffer
+	validator := newTestValidatorWithOptions(t, `#Config: {name: string, port: int}`, ValidationOptions{
+		Logger: slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	result, err := validator.ValidateStructural(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte(`{"port": "x"}`),
+		Format:     FormatJSON,
+		Name:       "partial.json",
+	})
+	if err != nil {
+		t.Fatalf("ValidateStructural failed: %v", err)
+	}
+	if result.Valid || result.Duration <= 0 {
+		t.Errorf("got Valid = %v, Duration = %v, want invalid with a duration", result.Valid, result.Duration)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(output.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON log record, got %q: %v", output.String(), err)
+	}
+	if record["input"] != "partial.json" || record["valid"] != false {
+		t.Errorf("unexpected log record: %v", record)
+	}
+}
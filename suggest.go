@@ -0,0 +1,92 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/errors"
+)
+
+// suggestFieldNames replaces the message of each "field not allowed" error
+// with one naming the closest field configDef declares at the same level,
+// e.g. `unknown field "reigon"; did you mean "region"?`. Errors without a
+// close enough field keep their message. errs must have been extracted
+// from err.
+func suggestFieldNames(configDef cue.Value, err error, errs []ValidationError) {
+	cueErrors := errors.Errors(err)
+	if len(cueErrors) != len(errs) {
+		return
+	}
+
+	for i, e := range cueErrors {
+		errPath := e.Path()
+		if len(errPath) == 0 || !strings.HasSuffix(errs[i].Message, "field not allowed") {
+			continue
+		}
+		name := errPath[len(errPath)-1]
+		if unquoted, err := strconv.Unquote(name); err == nil {
+			name = unquoted
+		}
+		parent := configDef
+		for _, sel := range inputPath(errPath[:len(errPath)-1]).Selectors() {
+			parent = lookupSchemaField(parent, sel)
+		}
+		if suggestion, ok := closestFieldName(parent, name); ok {
+			errs[i].Message = fmt.Sprintf("unknown field %q; did you mean %q?", name, suggestion)
+		}
+	}
+}
+
+// closestFieldName returns the field the schema struct declares that is
+// nearest to name by edit distance, ignoring case. A field qualifies if
+// at most a third of name's characters (and at least one) must change.
+// Ties go to the field declared first.
+func closestFieldName(parent cue.Value, name string) (string, bool) {
+	iter, err := parent.Fields(cue.Optional(true))
+	if err != nil {
+		return "", false
+	}
+
+	best, bestDistance := "", max(1, len([]rune(name))/3)+1
+	for iter.Next() {
+		candidate := iter.Selector().Unquoted()
+		if candidate == name {
+			continue
+		}
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the number of single-character insertions,
+// deletions, substitutions, and transpositions of adjacent characters
+// needed to turn a into b (optimal string alignment distance)
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
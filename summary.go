@@ -0,0 +1,148 @@
+package cuebridge
+
+import (
+	"iter"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CountInvalid returns the number of results that failed validation.
+func CountInvalid(results []ValidationResult) int {
+	count := 0
+	for _, result := range results {
+		if !result.Valid {
+			count++
+		}
+	}
+	return count
+}
+
+// TotalErrors returns the number of individual errors across all results,
+// including warnings.
+func TotalErrors(results []ValidationResult) int {
+	total := 0
+	for _, result := range results {
+		total += len(result.Errors)
+	}
+	return total
+}
+
+// AllErrors returns an iterator over every error across results, paired
+// with the name of the result it belongs to, for use with range:
+//
+//	for name, e := range cuebridge.AllErrors(results) {
+//		fmt.Printf("%s: %v\n", name, e)
+//	}
+func AllErrors(results []ValidationResult) iter.Seq2[string, ValidationError] {
+	return func(yield func(string, ValidationError) bool) {
+		for _, result := range results {
+			for _, e := range result.Errors {
+				if !yield(result.Name, e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FilterBySeverity returns copies of results keeping only errors at least
+// as severe as min (see Severity.AtLeast). Valid is recomputed from the
+// remaining errors: a result is valid if none of them is a SeverityError.
+// For example, FilterBySeverity(results, SeverityError) drops all warnings.
+func FilterBySeverity(results []ValidationResult, min Severity) []ValidationResult {
+	filtered := make([]ValidationResult, len(results))
+	for i, result := range results {
+		errs := []ValidationError{}
+		valid := true
+		for _, e := range result.Errors {
+			if !e.Severity.AtLeast(min) {
+				continue
+			}
+			errs = append(errs, e)
+			if e.Severity == SeverityError {
+				valid = false
+			}
+		}
+		result.Errors = errs
+		result.Valid = valid
+		filtered[i] = result
+	}
+	return filtered
+}
+
+// RelativeTo returns copies of results whose absolute file path names
+// (e.g., from ValidateDir with an absolute root) are shortened to paths
+// relative to base. Names outside base, relative names, and all names
+// when base is empty are kept as they are.
+func RelativeTo(results []ValidationResult, base string) []ValidationResult {
+	relative := make([]ValidationResult, len(results))
+	for i, result := range results {
+		if base != "" && filepath.IsAbs(result.Name) {
+			rel, err := filepath.Rel(base, result.Name)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				result.Name = rel
+			}
+		}
+		relative[i] = result
+	}
+	return relative
+}
+
+// MergeResults combines the results of validating one input against
+// several independent validators (e.g., a naming schema and a security
+// schema) into a single result:
+//   - Valid is true only if every result is valid
+//   - Errors are the errors of all results, in order, each with the
+//     SchemaPath and Definition of its result (unless already set)
+//   - Name, SchemaPath, and Definition join the distinct non-empty values
+//     of the results with " | " (so one input keeps its name)
+//   - ParseFailed is set if any result failed to parse, and Duration is
+//     the sum of the durations
+//
+// Merging no results returns a valid result without errors.
+func MergeResults(results ...ValidationResult) ValidationResult {
+	merged := ValidationResult{Valid: true, Errors: []ValidationError{}}
+	var names, schemaPaths, definitions []string
+	for _, result := range results {
+		merged.Valid = merged.Valid && result.Valid
+		merged.Errors = append(merged.Errors, errorsWithSource(result)...)
+		merged.ParseFailed = merged.ParseFailed || result.ParseFailed
+		merged.Duration += result.Duration
+		names = appendDistinct(names, result.Name)
+		schemaPaths = appendDistinct(schemaPaths, result.SchemaPath)
+		definitions = appendDistinct(definitions, result.Definition)
+	}
+	merged.Name = strings.Join(names, resultSeparator)
+	merged.SchemaPath = strings.Join(schemaPaths, resultSeparator)
+	merged.Definition = strings.Join(definitions, resultSeparator)
+	return merged
+}
+
+// resultSeparator joins the names, schema paths, and definitions of
+// combined results
+const resultSeparator = " | "
+
+// errorsWithSource returns copies of the errors of result with SchemaPath
+// and Definition taken from the result where the error has none
+func errorsWithSource(result ValidationResult) []ValidationError {
+	errs := make([]ValidationError, len(result.Errors))
+	for i, e := range result.Errors {
+		if e.SchemaPath == "" {
+			e.SchemaPath = result.SchemaPath
+		}
+		if e.Definition == "" {
+			e.Definition = result.Definition
+		}
+		errs[i] = e
+	}
+	return errs
+}
+
+// appendDistinct appends s to values unless it is empty or already present
+func appendDistinct(values []string, s string) []string {
+	if s == "" || slices.Contains(values, s) {
+		return values
+	}
+	return append(values, s)
+}
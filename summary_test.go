@@ -0,0 +1,193 @@
+package cuebridge
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestSummaryHelpers tests the aggregate counts over a batch
+func TestSummaryHelpers(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "a", Valid: true, Errors: []ValidationError{}},
+		{Name: "b", Valid: false, Errors: []ValidationError{{Message: "x"}, {Message: "y"}}},
+		{Name: "c", Valid: false, Errors: []ValidationError{{Message: "z"}}},
+	}
+
+	if got := CountInvalid(results); got != 2 {
+		t.Errorf("CountInvalid = %d, want 2", got)
+	}
+	if got := TotalErrors(results); got != 3 {
+		t.Errorf("TotalErrors = %d, want 3", got)
+	}
+	if got := TotalErrors(nil); got != 0 {
+		t.Errorf("TotalErrors(nil) = %d, want 0", got)
+	}
+}
+
+// TestAllErrors tests iterating over errors across a batch
+func TestAllErrors(t *testing.T) {
+	results := []ValidationResult{
+		{Name: "a", Valid: true, Errors: []ValidationError{}},
+		{Name: "b", Valid: false, Errors: []ValidationError{{Message: "x"}, {Message: "y"}}},
+		{Name: "c", Valid: false, Errors: []ValidationError{{Message: "z"}}},
+	}
+
+	var got []string
+	for name, e := range AllErrors(results) {
+		got = append(got, name+":"+e.Message)
+	}
+	want := []string{"b:x", "b:y", "c:z"}
+	if !slices.Equal(got, want) {
+		t.Errorf("AllErrors yielded %v, want %v", got, want)
+	}
+
+	// Stopping early ends the iteration
+	count := 0
+	for range AllErrors(results) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("iterated %d times after break, want 1", count)
+	}
+}
+
+// TestFilterBySeverity tests dropping errors below a severity threshold
+func TestFilterBySeverity(t *testing.T) {
+	warning := ValidationError{Path: "old", Message: "deprecated", Severity: SeverityWarning}
+	failure := ValidationError{Path: "port", Message: "out of range", Severity: SeverityError}
+	results := []ValidationResult{
+		{Name: "clean", Valid: true, Errors: []ValidationError{}},
+		{Name: "warned", Valid: true, Errors: []ValidationError{warning}},
+		{Name: "mixed", Valid: false, Errors: []ValidationError{warning, failure}},
+		{Name: "strict", Valid: false, Errors: []ValidationError{warning}},
+	}
+
+	tests := []struct {
+		min        Severity
+		wantValid  []bool
+		wantCounts []int
+	}{
+		{min: SeverityWarning, wantValid: []bool{true, true, false, true}, wantCounts: []int{0, 1, 2, 1}},
+		{min: SeverityError, wantValid: []bool{true, true, false, true}, wantCounts: []int{0, 0, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.min.String(), func(t *testing.T) {
+			filtered := FilterBySeverity(results, tt.min)
+			if len(filtered) != len(results) {
+				t.Fatalf("got %d results, want %d", len(filtered), len(results))
+			}
+			for i, result := range filtered {
+				if result.Valid != tt.wantValid[i] {
+					t.Errorf("%s: Valid = %v, want %v", result.Name, result.Valid, tt.wantValid[i])
+				}
+				if len(result.Errors) != tt.wantCounts[i] {
+					t.Errorf("%s: got %d errors, want %d", result.Name, len(result.Errors), tt.wantCounts[i])
+				}
+			}
+		})
+	}
+
+	// The input results are left untouched
+	if len(results[2].Errors) != 2 {
+		t.Errorf("input modified: %v", results[2].Errors)
+	}
+}
+
+// TestRelativeTo tests shortening result names to a base directory
+func TestRelativeTo(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "configs")
+	results := []ValidationResult{
+		{Name: filepath.Join(base, "app.yaml")},
+		{Name: filepath.Join(base, "prod", "db.json")},
+		{Name: filepath.Join(filepath.Dir(base), "other.yaml")},
+		{Name: "stdin"},
+	}
+
+	tests := []struct {
+		name string
+		base string
+		want []string
+	}{
+		{
+			name: "relative to base",
+			base: base,
+			want: []string{"app.yaml", filepath.Join("prod", "db.json"), results[2].Name, "stdin"},
+		},
+		{
+			name: "no base",
+			base: "",
+			want: []string{results[0].Name, results[1].Name, results[2].Name, "stdin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, result := range RelativeTo(results, tt.base) {
+				got = append(got, result.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMergeResults tests combining results from several validators
+func TestMergeResults(t *testing.T) {
+	naming := ValidationResult{Name: "app.yaml", Valid: true, Errors: []ValidationError{}, SchemaPath: "naming.cue", Definition: "#Config", Duration: 2}
+	security := ValidationResult{Name: "app.yaml", Valid: false, Errors: []ValidationError{{Path: "privileged", Message: "conflicting values false and true"}}, SchemaPath: "security.cue", Definition: "#Config", Duration: 3}
+	limits := ValidationResult{Name: "app.yaml", Valid: true, Errors: []ValidationError{{Path: "cpu", Message: "no limit set", Severity: SeverityWarning}}, SchemaPath: "limits.cue", Definition: "#Limits", Duration: 5}
+
+	tests := []struct {
+		name           string
+		results        []ValidationResult
+		wantValid      bool
+		wantName       string
+		wantSchemaPath string
+		wantDefinition string
+		wantPaths      []string
+	}{
+		{name: "none", wantValid: true, wantPaths: []string{}},
+		{name: "all valid", results: []ValidationResult{naming, limits}, wantValid: true, wantName: "app.yaml", wantSchemaPath: "naming.cue | limits.cue", wantDefinition: "#Config | #Limits", wantPaths: []string{"cpu"}},
+		{name: "valid and invalid", results: []ValidationResult{naming, security, limits}, wantName: "app.yaml", wantSchemaPath: "naming.cue | security.cue | limits.cue", wantDefinition: "#Config | #Limits", wantPaths: []string{"privileged", "cpu"}},
+		{name: "different inputs", results: []ValidationResult{naming, {Name: "db.yaml", Valid: true}}, wantValid: true, wantName: "app.yaml | db.yaml", wantSchemaPath: "naming.cue", wantDefinition: "#Config", wantPaths: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := MergeResults(tt.results...)
+			if merged.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v", merged.Valid, tt.wantValid)
+			}
+			if merged.Name != tt.wantName || merged.SchemaPath != tt.wantSchemaPath || merged.Definition != tt.wantDefinition {
+				t.Errorf("got %q, %q, %q, want %q, %q, %q", merged.Name, merged.SchemaPath, merged.Definition, tt.wantName, tt.wantSchemaPath, tt.wantDefinition)
+			}
+			paths := []string{}
+			for _, e := range merged.Errors {
+				paths = append(paths, e.Path)
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+
+	merged := MergeResults(naming, security, limits)
+	if merged.Duration != 10 {
+		t.Errorf("Duration = %v, want 10", merged.Duration)
+	}
+	var sources []string
+	for _, e := range merged.Errors {
+		sources = append(sources, e.SchemaPath+" "+e.Definition)
+	}
+	if want := []string{"security.cue #Config", "limits.cue #Limits"}; !slices.Equal(sources, want) {
+		t.Errorf("error sources = %v, want %v", sources, want)
+	}
+	if security.Errors[0].SchemaPath != "" {
+		t.Errorf("MergeResults modified its input: %v", security.Errors)
+	}
+}
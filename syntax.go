@@ -0,0 +1,30 @@
+package cuebridge
+
+import (
+	"cuelang.org/go/cue/cuecontext"
+)
+
+// CheckSyntax reports whether data is well-formed JSON or YAML without
+// validating it against a schema, e.g. as a fast pre-check.
+// With FormatAuto the format is detected from name's extension,
+// falling back to the content.
+//
+// Returns the parse errors with their positions, or nil if data parses.
+func CheckSyntax(data []byte, format DataFormat, name string) []ValidationError {
+	data, err := decodeText(data)
+	if err != nil {
+		return createErrorResult(name, err.Error()).Errors
+	}
+
+	source := &sourceContext{name: name, filename: name, data: data}
+	format = resolveFormat(ValidationInput{SourceType: SourceBytes, Name: name, Format: format}, data)
+
+	value, err := parseData(cuecontext.New(), data, format, name, parseOptions{})
+	if err != nil {
+		return createParseErrorResult(name, err).Errors
+	}
+	if err := value.Err(); err != nil {
+		return createValidationErrorResult(source, err).Errors
+	}
+	return nil
+}
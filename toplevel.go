@@ -0,0 +1,65 @@
+package cuebridge
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+)
+
+// findUnknownTopLevelFields reports an error for each top-level field of data
+// that the definition does not declare, even if the definition is open.
+// root is the path of data within the input (e.g., the InputPath). Fields
+// a pattern constraint applies to (e.g., [=~"^x-"]: string) count as
+// declared, unless the pattern leaves them unconstrained ([string]: _).
+// Fields the definition does not allow are skipped, since unification
+// already reports them.
+func findUnknownTopLevelFields(configDef cue.Value, data cue.Value, root cue.Path) []ValidationError {
+	if data.IncompleteKind() != cue.StructKind {
+		return nil
+	}
+
+	declared := map[string]bool{}
+	iter, err := configDef.Fields(cue.Optional(true))
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		declared[iter.Selector().Unquoted()] = true
+	}
+
+	var prefix []string
+	for _, sel := range root.Selectors() {
+		prefix = append(prefix, sel.String())
+	}
+
+	var errs []ValidationError
+	iter, err = data.Fields()
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		name := iter.Selector().Unquoted()
+		if declared[name] || matchesPattern(configDef, name) || !configDef.Allows(cue.Str(name)) {
+			continue
+		}
+
+		path := append(prefix[:len(prefix):len(prefix)], iter.Selector().String())
+		errs = append(errs, ValidationError{
+			Line:    iter.Value().Pos().Line(),
+			Column:  iter.Value().Pos().Column(),
+			Path:    formatPath(path),
+			Pointer: jsonPointer(path),
+			Message: fmt.Sprintf("unknown top-level field %q", name),
+		})
+	}
+	return errs
+}
+
+// matchesPattern reports whether a pattern constraint of configDef
+// constrains the field name. A field added to the definition is only
+// constrained, rather than left as top like under "...", by a pattern.
+func matchesPattern(configDef cue.Value, name string) bool {
+	path := cue.MakePath(cue.Str(name))
+	field := configDef.FillPath(path, configDef.Context().CompileString("_")).LookupPath(path)
+	return field.IncompleteKind() != cue.TopKind
+}
@@ -0,0 +1,148 @@
+package cuebridge
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestStrictTopLevel tests rejecting unknown top-level fields in open schemas
+func TestStrictTopLevel(t *testing.T) {
+	schema := `
+#Config: {
+	name: string
+	spec?: {replicas?: int, ...}
+	...
+}`
+
+	tests := []struct {
+		name       string
+		data       string
+		strict     bool
+		wantValid  bool
+		wantErrors int
+		wantLine   int
+	}{
+		{
+			name:      "nested extra allowed",
+			data:      "name: app\nspec:\n  replicas: 1\n  extra: true\n",
+			strict:    true,
+			wantValid: true,
+		},
+		{
+			name:       "top-level extra rejected",
+			data:       "name: app\nspecc:\n  replicas: 1\n",
+			strict:     true,
+			wantValid:  false,
+			wantErrors: 1,
+			wantLine:   2,
+		},
+		{
+			name:      "top-level extra allowed without option",
+			data:      "name: app\nspecc:\n  replicas: 1\n",
+			strict:    false,
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestValidatorWithOptions(t, schema, ValidationOptions{StrictTopLevel: tt.strict})
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatYAML,
+				Name:       "config.yaml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Fatalf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			if tt.wantErrors > 0 {
+				if len(result.Errors) != tt.wantErrors {
+					t.Fatalf("got %d errors, want %d: %v", len(result.Errors), tt.wantErrors, result.Errors)
+				}
+				if e := result.Errors[0]; e.Path != "specc" || e.Line != tt.wantLine {
+					t.Errorf("error = %+v, want path specc at line %d", e, tt.wantLine)
+				}
+			}
+		})
+	}
+
+	// Closed definitions report the field once, through unification
+	closed := newTestValidatorWithOptions(t, `#Config: {name: string}`, ValidationOptions{StrictTopLevel: true})
+	result, err := closed.Validate(ValidationInput{
+		SourceType: SourceBytes,
+		Data:       []byte("name: app\nextra: 1\n"),
+		Format:     FormatYAML,
+		Name:       "config.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if result.Valid || len(result.Errors) != 1 {
+		t.Errorf("expected a single error, got %v", result.Errors)
+	}
+}
+
+// TestStrictTopLevelPatternsAndSections tests fields matched by pattern
+// constraints and inputs checked in part
+func TestStrictTopLevelPatternsAndSections(t *testing.T) {
+	validator := newTestValidatorWithOptions(t, `#Config: {
+	name: string
+	[=~"^x-"]: string
+	...
+}
+#Server: {port: int, ...}`, ValidationOptions{StrictTopLevel: true})
+
+	tests := []struct {
+		name      string
+		data      string
+		inputPath string
+		wantPaths []string
+	}{
+		{name: "pattern field", data: `{"name": "app", "x-team": "infra"}`},
+		{name: "pattern field checked", data: `{"name": "app", "x-team": 1}`, wantPaths: []string{"x-team"}},
+		{name: "other field", data: `{"name": "app", "team": "infra"}`, wantPaths: []string{"team"}},
+		{name: "outside input path", data: `{"version": 1, "app": {"name": "app"}}`, inputPath: "app"},
+		{name: "inside input path", data: `{"version": 1, "app": {"name": "app", "team": "infra"}}`, inputPath: "app", wantPaths: []string{"app.team"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.Validate(ValidationInput{
+				SourceType: SourceBytes,
+				Data:       []byte(tt.data),
+				Format:     FormatJSON,
+				Name:       "config.json",
+				InputPath:  tt.inputPath,
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if result.Valid != (len(tt.wantPaths) == 0) || !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("Valid = %v, error paths = %v, want %v", result.Valid, paths, tt.wantPaths)
+			}
+		})
+	}
+
+	t.Run("sections", func(t *testing.T) {
+		result, err := validator.ValidateSections(ValidationInput{
+			SourceType: SourceBytes,
+			Data:       []byte(`{"app": {"name": "app"}, "server": {"port": 80, "host": "a"}}`),
+			Format:     FormatJSON,
+			Name:       "config.json",
+		}, map[string]string{"app": "#Config", "server": "#Server"})
+		if err != nil {
+			t.Fatalf("ValidateSections failed: %v", err)
+		}
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Path != "server.host" {
+			t.Errorf("Valid = %v, errors = %v, want one error at server.host", result.Valid, result.Errors)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package cuebridge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnionValidator validates data against several schemas, accepting input
+// that matches any of them.
+// Create a UnionValidator with NewValidatorUnion.
+type UnionValidator struct {
+	schemaPaths []string
+	validators  []*Validator
+}
+
+// NewValidatorUnion creates a UnionValidator from several CUE schema files
+// that each define definitionName (e.g., two product generations of #Config).
+//
+// Returns an error if no schema is given or any schema fails to load
+// (see NewValidator).
+func NewValidatorUnion(schemaPaths []string, definitionName string) (*UnionValidator, error) {
+	if len(schemaPaths) == 0 {
+		return nil, fmt.Errorf("no schema files given")
+	}
+
+	union := &UnionValidator{schemaPaths: schemaPaths}
+	for _, schemaPath := range schemaPaths {
+		validator, err := newValidator(schemaPath, definitionName, ValidationOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", schemaPath, err)
+		}
+		union.validators = append(union.validators, validator)
+	}
+
+	return union, nil
+}
+
+// Validate validates input against each schema in order and returns the
+// result for the first schema it matches, along with that schema's path.
+//
+// If no schema matches, the returned result is invalid, contains the errors
+// from every schema, and the schema path is empty.
+// Returns an error only if the validation process itself fails.
+func (u *UnionValidator) Validate(input ValidationInput) (ValidationResult, string, error) {
+	if err := input.Validate(); err != nil {
+		return ValidationResult{}, "", err
+	}
+	// Drain a reader once so that it can be validated against every
+	// schema; each validator decodes and checks the input as usual
+	if input.SourceType == SourceReader {
+		data, err := readRawInput(input)
+		if err != nil {
+			return ValidationResult{}, "", fmt.Errorf("reading input: %w", err)
+		}
+		input.SourceType = SourceBytes
+		input.Data = data
+		input.Reader = nil
+	}
+
+	combined := ValidationResult{
+		Name:       input.Name,
+		Valid:      false,
+		SchemaPath: strings.Join(u.schemaPaths, resultSeparator),
+		Definition: u.validators[0].definitionName,
+	}
+	for i, validator := range u.validators {
+		result, err := validator.validate(input)
+		if err != nil {
+			return ValidationResult{}, "", err
+		}
+		if result.Valid {
+			return result, u.schemaPaths[i], nil
+		}
+		combined.Errors = append(combined.Errors, errorsWithSource(result)...)
+	}
+
+	return combined, "", nil
+}
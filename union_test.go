@@ -0,0 +1,86 @@
+package cuebridge
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidatorUnion tests accepting input that matches any schema
+func TestValidatorUnion(t *testing.T) {
+	tmpDir := t.TempDir()
+	schemas := map[string]string{
+		"v1.cue": `#Config: {name: string, size: "small" | "large"}`,
+		"v2.cue": `#Config: {name: string, cpu: int, memory: string}`,
+	}
+	var schemaPaths []string
+	for _, name := range []string{"v1.cue", "v2.cue"} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(schemas[name]), 0644); err != nil {
+			t.Fatalf("failed to write schema: %v", err)
+		}
+		schemaPaths = append(schemaPaths, path)
+	}
+
+	union, err := NewValidatorUnion(schemaPaths, "#Config")
+	if err != nil {
+		t.Fatalf("NewValidatorUnion failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantValid   bool
+		wantMatched string
+	}{
+		{name: "matches v1", content: "name: a\nsize: small\n", wantValid: true, wantMatched: "v1.cue"},
+		{name: "matches v2", content: "name: a\ncpu: 2\nmemory: 1Gi\n", wantValid: true, wantMatched: "v2.cue"},
+		{name: "matches neither", content: "name: a\n", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, matched, err := union.Validate(ValidationInput{
+				SourceType: SourceReader,
+				Reader:     strings.NewReader(tt.content),
+				Format:     FormatYAML,
+				Name:       "config.yaml",
+			})
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (errors: %v)", result.Valid, tt.wantValid, result.Errors)
+			}
+			wantMatched := ""
+			if tt.wantMatched != "" {
+				wantMatched = filepath.Join(tmpDir, tt.wantMatched)
+			}
+			if matched != wantMatched {
+				t.Errorf("matched = %q, want %q", matched, wantMatched)
+			}
+			if !tt.wantValid && len(result.Errors) < 2 {
+				t.Errorf("expected errors from both schemas, got %v", result.Errors)
+			}
+		})
+	}
+
+	t.Run("encoded reader", func(t *testing.T) {
+		encoded := base64.StdEncoding.EncodeToString([]byte("name: a\r\nsize: large\r\n"))
+		result, matched, err := union.Validate(ValidationInput{
+			SourceType: SourceReader,
+			Reader:     strings.NewReader(encoded),
+			Format:     FormatYAML,
+			Encoding:   EncodingBase64,
+			Name:       "config.yaml",
+		})
+		if err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if !result.Valid || matched != schemaPaths[0] {
+			t.Errorf("got %v, %q, want valid against %s (errors: %v)", result.Valid, matched, schemaPaths[0], result.Errors)
+		}
+	})
+}
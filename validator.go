@@ -1,28 +1,116 @@
 package cuebridge
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"time"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/build"
 	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
 )
 
 // newValidator creates a new Validator by loading and compiling a CUE schema
-func newValidator(schemaPath string, definitionName string) (*Validator, error) {
-	// Read schema file
-	schemaData, err := os.ReadFile(schemaPath)
+func newValidator(schemaPath string, definitionName string, opts ValidationOptions) (*Validator, error) {
+	// Create CUE context
+	ctx := cuecontext.New()
+
+	// Load and compile schema
+	instance, err := loadSchemaInstance(schemaPath, opts)
 	if err != nil {
-		return nil, fmt.Errorf("reading schema file: %w", err)
+		return nil, err
+	}
+	schema := ctx.BuildInstance(instance)
+	if schema.Err() != nil {
+		return nil, fmt.Errorf("compiling schema: %w", schema.Err())
 	}
 
-	// Create CUE context
-	ctx := cuecontext.New()
+	validator, err := newValidatorFromValue(schema, schemaPath, definitionName, opts)
+	if err != nil {
+		return nil, err
+	}
+	// A package directory has several files, which the compiled value
+	// does not return as its source
+	validator.schemaFiles = instance.Files
+	return validator, nil
+}
+
+// compileSchemaFile loads and compiles a CUE schema file, or the package in
+// a schema directory. Imports are resolved relative to the schema's directory
+// (the enclosing CUE module), not the process working directory.
+// Of the options, Package selects the package to load and
+// DisallowedImports restricts what the schema may import.
+func compileSchemaFile(ctx *cue.Context, schemaPath string, opts ValidationOptions) (cue.Value, error) {
+	instance, err := loadSchemaInstance(schemaPath, opts)
+	if err != nil {
+		return cue.Value{}, err
+	}
 
 	// Compile schema
-	schema := ctx.CompileBytes(schemaData, cue.Filename(schemaPath))
+	schema := ctx.BuildInstance(instance)
 	if schema.Err() != nil {
-		return nil, fmt.Errorf("compiling schema: %w", schema.Err())
+		return cue.Value{}, fmt.Errorf("compiling schema: %w", schema.Err())
+	}
+
+	return schema, nil
+}
+
+// loadSchemaInstance loads a CUE schema file, or the package in a schema
+// directory, as a build instance for compileSchemaFile
+func loadSchemaInstance(schemaPath string, opts ValidationOptions) (*build.Instance, error) {
+	// Check the file up front for a clearer error than the loader's
+	info, err := os.Stat(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema file: %w", err)
+	}
+
+	absPath, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema file: %w", err)
+	}
+
+	// Load a directory as the package "." within it, qualified by name if given
+	arg, dir := absPath, filepath.Dir(absPath)
+	if info.IsDir() {
+		arg, dir = ".", absPath
+		if opts.Package != "" {
+			arg = ".:" + opts.Package
+		}
+	}
+
+	instances := load.Instances([]string{arg}, &load.Config{
+		Dir: dir,
+	})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("loading schema: expected 1 instance, got %d", len(instances))
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
+	}
+	if opts.Package != "" && instances[0].PkgName != opts.Package {
+		return nil, fmt.Errorf("loading schema: %s is in package %q, not %q", schemaPath, instances[0].PkgName, opts.Package)
+	}
+	if err := checkImports(instances[0], opts.DisallowedImports); err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
+	}
+
+	return instances[0], nil
+}
+
+// newValidatorFromValue creates a new Validator from an already compiled schema
+func newValidatorFromValue(schema cue.Value, schemaPath string, definitionName string, opts ValidationOptions) (*Validator, error) {
+	ctx := schema.Context()
+	if ctx == nil {
+		return nil, fmt.Errorf("schema value has no CUE context")
+	}
+	if schema.Err() != nil {
+		return nil, fmt.Errorf("invalid schema: %w", schema.Err())
 	}
 
 	// Verify definition exists
@@ -30,55 +118,347 @@ func newValidator(schemaPath string, definitionName string) (*Validator, error)
 	if !configDef.Exists() {
 		return nil, fmt.Errorf("schema does not define %s", definitionName)
 	}
+	if err := checkUnresolvedReferences(definitionName, configDef); err != nil {
+		return nil, err
+	}
+
+	if err := checkDiscriminator(schema, opts); err != nil {
+		return nil, err
+	}
+
+	// The source is taken before overrides, which leave the value without one
+	var schemaFiles []*ast.File
+	if file, ok := schema.Source().(*ast.File); ok {
+		schemaFiles = []*ast.File{file}
+	}
+
+	if len(opts.Overrides) > 0 {
+		var err error
+		schema, err = applyOverrides(schema, definitionName, opts.Overrides)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	return &Validator{
+	validator := &Validator{
 		schemaPath:     schemaPath,
 		definitionName: definitionName,
 		ctx:            ctx,
 		compiledSchema: schema,
-	}, nil
+		schemaFiles:    schemaFiles,
+		options:        opts,
+	}
+	if opts.CacheSize > 0 {
+		validator.cache = newResultCache(opts.CacheSize)
+	}
+	return validator, nil
+}
+
+// parsedInput is input data parsed into a CUE value
+type parsedInput struct {
+	value  cue.Value
+	format DataFormat
+	source *sourceContext
+	// structural skips the requirement that values be concrete
+	structural bool
+	// root is the path of the part of the input checked against the
+	// definition (ValidationInput.InputPath), empty for the whole input
+	root cue.Path
+	// floatEpsilon, if positive, is the tolerance for float comparisons
+	floatEpsilon float64
+	// schemaFiles is the schema syntax searched for conditions around
+	// failing fields (nil to skip)
+	schemaFiles []*ast.File
+}
+
+// sourceContext carries the raw input used for error extraction
+type sourceContext struct {
+	// name is the result name
+	name string
+	// filename is the file name recorded in positions of the parsed input
+	filename string
+	// data is the raw input as read, kept for every source type so that
+	// source lines are available without re-reading the input
+	data []byte
+	// captureLines enables filling ValidationError.SourceLine
+	captureLines bool
+	// preferInputPositions selects line/column from input-side positions first
+	preferInputPositions bool
+	// value is the parsed input, once parsing succeeded
+	value cue.Value
 }
 
-// validate validates a single input against the schema
+// validate validates a single input against the schema,
+// logging the outcome if a logger is configured
 func (v *Validator) validate(input ValidationInput) (ValidationResult, error) {
+	if v.cache != nil {
+		return v.validateWith(input, v.validateCached)
+	}
+	return v.validateWith(input, v.validateInput)
+}
+
+// validateWith checks input and runs check on it, recording the duration
+// and logging the outcome if a logger is configured
+func (v *Validator) validateWith(input ValidationInput, check func(ValidationInput) (ValidationResult, error)) (ValidationResult, error) {
+	if err := input.Validate(); err != nil {
+		v.logValidation(input.Name, ValidationResult{}, err, 0)
+		return ValidationResult{}, err
+	}
+
+	return v.timeValidation(input.Name, func() (ValidationResult, error) {
+		return check(input)
+	})
+}
+
+// timeValidation runs check, recording the duration in its result and
+// logging the outcome under name if a logger is configured
+func (v *Validator) timeValidation(name string, check func() (ValidationResult, error)) (ValidationResult, error) {
+	start := time.Now()
+	result, err := check()
+	duration := time.Since(start)
+	if err == nil {
+		result.Duration = duration
+	}
+	v.logValidation(name, result, err, duration)
+	return result, err
+}
+
+// logValidation logs a validation outcome at debug level
+func (v *Validator) logValidation(name string, result ValidationResult, err error, duration time.Duration) {
+	if v.options.Logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("schema", v.schemaPath),
+		slog.String("input", name),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Bool("valid", result.Valid), slog.Int("errors", len(result.Errors)))
+	}
+	v.options.Logger.LogAttrs(context.Background(), slog.LevelDebug, "cuebridge validation", attrs...)
+}
+
+// validateInput reads, parses, and checks a single input
+func (v *Validator) validateInput(input ValidationInput) (ValidationResult, error) {
+	// Read and parse input data
+	parsed, failed, err := v.parseInput(input)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if failed != nil {
+		return *failed, nil
+	}
+
+	// Get definition from schema, placed at the selected part of the input
+	definitionName, configDef, failed, err := v.inputDefinition(input, &parsed)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if failed != nil {
+		return *failed, nil
+	}
+
+	return v.checkInput(definitionName, configDef, parsed), nil
+}
+
+// inputDefinition returns the definition an input is validated against:
+// input.Definition, the definition selected by the discriminator field, or
+// the validator's definition, placed at input.InputPath so that only that
+// part of the input is checked. A non-nil result is returned instead when
+// the input has no value at InputPath or no definition for its
+// discriminator. The path is recorded in parsed.root.
+func (v *Validator) inputDefinition(input ValidationInput, parsed *parsedInput) (string, cue.Value, *ValidationResult, error) {
+	parsed.root = cue.Path{}
+
+	definitionName := v.definitionName
+	if input.Definition != "" {
+		definitionName = input.Definition
+	}
+
+	var path cue.Path
+	data := parsed.value
+	if input.InputPath != "" {
+		path = cue.ParsePath(input.InputPath)
+		if path.Err() != nil {
+			return "", cue.Value{}, nil, fmt.Errorf("invalid input path %s: %w", input.InputPath, path.Err())
+		}
+		if data = parsed.value.LookupPath(path); !data.Exists() {
+			result := v.withProvenance(createErrorResult(input.Name, fmt.Sprintf("input has no value at %s", input.InputPath)))
+			result.Definition = definitionName
+			result.Errors[0].Path = input.InputPath
+			return "", cue.Value{}, &result, nil
+		}
+	}
+
+	if input.Definition == "" && v.options.DiscriminatorField != "" {
+		name, failure := v.discriminatedDefinition(data, input.InputPath)
+		if failure != nil {
+			result := v.withProvenance(ValidationResult{Name: input.Name, Errors: []ValidationError{*failure}})
+			return "", cue.Value{}, &result, nil
+		}
+		definitionName = name
+	}
+
+	configDef, err := v.lookupDefinition(definitionName)
+	if err != nil {
+		return "", cue.Value{}, nil, err
+	}
+	if input.InputPath == "" {
+		return definitionName, configDef, nil, nil
+	}
+
+	// Fields outside InputPath unify with top and are left unchecked
+	parsed.root = path
+	return definitionName, v.ctx.CompileString("_").FillPath(path, configDef), nil, nil
+}
+
+// checkInput validates parsed input against a definition,
+// including the optional checks enabled in the validator options
+func (v *Validator) checkInput(definitionName string, configDef cue.Value, parsed parsedInput) ValidationResult {
+	parsed = v.withoutAllowedExtras(configDef, parsed)
+	parsed.floatEpsilon = v.floatEpsilon()
+	parsed.schemaFiles = v.schemaFiles
+	result := checkValue(configDef, parsed)
+	result.SchemaPath = v.schemaPath
+	result.Definition = definitionName
+
+	if v.options.StrictTopLevel {
+		definition, data := configDef.LookupPath(parsed.root), parsed.value.LookupPath(parsed.root)
+		if unknown := findUnknownTopLevelFields(definition, data, parsed.root); len(unknown) > 0 {
+			result.Errors = append(result.Errors, unknown...)
+			result.Valid = false
+		}
+	}
+
+	if v.options.ReportDeprecated {
+		warnings := findDeprecatedFields(configDef.Unify(parsed.value), parsed.value)
+		result.Errors = append(result.Errors, warnings...)
+	}
+
+	if v.options.ReportRecommended {
+		warnings := findMissingRecommended(configDef.Unify(parsed.value), parsed.value)
+		result.Errors = append(result.Errors, warnings...)
+	}
+
+	if result.Valid && v.options.PostValidate != nil {
+		for _, e := range v.options.PostValidate(configDef.Unify(parsed.value)) {
+			result.Errors = append(result.Errors, e)
+			if e.Severity == SeverityError {
+				result.Valid = false
+			}
+		}
+	}
+
+	return v.withPathFormatter(result)
+}
+
+// parseInput reads input data and parses it into a CUE value.
+// A non-nil result is returned instead of a value when the data cannot be parsed.
+func (v *Validator) parseInput(input ValidationInput) (parsedInput, *ValidationResult, error) {
 	// Read input data
-	data, err := readInput(input)
+	data, err := v.readInput(input)
 	if err != nil {
-		return ValidationResult{}, fmt.Errorf("reading input: %w", err)
+		return parsedInput{}, nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	source := &sourceContext{
+		name:                 input.Name,
+		filename:             input.Name,
+		data:                 data,
+		captureLines:         v.options.CaptureSourceLines,
+		preferInputPositions: v.options.PreferInputPositions,
 	}
 
 	// Parse data into CUE value
-	parsedData, err := parseData(v.ctx, data, input.Format, input.Name)
+	format := resolveFormat(input, data)
+	parsedData, err := parseData(v.ctx, data, format, input.Name, parseOptions{
+		proto3JSON: input.Proto3JSON,
+		yamlTags:   v.options.YAMLTagHandlers,
+		keyCase:    v.options.KeyCaseNormalization,
+	})
 	if err != nil {
-		return createErrorResult(input.Name, fmt.Sprintf("failed to parse: %v", err)), nil
+		result := v.withProvenance(withSourceLines(createParseErrorResult(input.Name, err), source))
+		return parsedInput{}, &result, nil
 	}
 
 	// Check for parse errors
 	if parsedData.Err() != nil {
-		return createValidationErrorResult(input.Name, parsedData.Err()), nil
+		result := v.withProvenance(createValidationErrorResult(source, parsedData.Err()))
+		result.ParseFailed = true
+		return parsedInput{}, &result, nil
+	}
+
+	// Reject oversized inputs before evaluating them against the schema
+	if err := newInputLimits(v.options).check(parsedData); err != nil {
+		result := v.withProvenance(createErrorResult(input.Name, err.Error()))
+		return parsedInput{}, &result, nil
+	}
+
+	if v.options.StrictYAML && format == FormatYAML {
+		if duplicates := findDuplicateYAMLKeys(data); len(duplicates) > 0 {
+			result := v.withProvenance(withSourceLines(ValidationResult{Name: input.Name, Valid: false, Errors: duplicates, ParseFailed: true}, source))
+			return parsedInput{}, &result, nil
+		}
 	}
 
-	// Get definition from schema
-	configDef := v.compiledSchema.LookupPath(cue.ParsePath(v.definitionName))
+	source.value = parsedData
+	return parsedInput{value: parsedData, format: format, source: source}, nil, nil
+}
+
+// withProvenance records the validator's schema path and definition in result
+func (v *Validator) withProvenance(result ValidationResult) ValidationResult {
+	result.SchemaPath = v.schemaPath
+	result.Definition = v.definitionName
+	return v.withPathFormatter(result)
+}
+
+// definition looks up the validator's definition in the compiled schema
+func (v *Validator) definition() (cue.Value, error) {
+	return v.lookupDefinition(v.definitionName)
+}
+
+// lookupDefinition looks up a definition by name in the compiled schema
+func (v *Validator) lookupDefinition(definitionName string) (cue.Value, error) {
+	configDef := v.compiledSchema.LookupPath(cue.ParsePath(definitionName))
 	if !configDef.Exists() {
-		return ValidationResult{}, fmt.Errorf("schema does not define %s", v.definitionName)
+		return cue.Value{}, fmt.Errorf("schema does not define %s", definitionName)
 	}
+	return configDef, nil
+}
 
+// checkValue unifies parsed input with a definition and validates the result
+func checkValue(configDef cue.Value, input parsedInput) ValidationResult {
 	// Unify data with schema
-	unified := configDef.Unify(parsedData)
+	unified := configDef.Unify(input.value)
 
-	// Validate
-	err = unified.Validate(cue.Concrete(true))
+	// Validate, excluding fields marked @abstract from the concrete requirement
+	var err error
+	if input.structural {
+		err = validateStructure(unified)
+	} else if err = unified.Validate(cue.Concrete(true)); err != nil {
+		err = withoutAbstractIncomplete(unified, err)
+	}
+	if err != nil && input.floatEpsilon > 0 {
+		err = withoutNearFloatErrors(err, input.floatEpsilon)
+	}
 	if err != nil {
-		return createValidationErrorResult(input.Name, err), nil
+		result := createValidationErrorResult(input.source, err)
+		suggestFieldNames(configDef, err, result.Errors)
+		annotateConditions(input.schemaFiles, unified, err, result.Errors)
+		applyMessageAttributes(unified, err, result.Errors)
+		return result
 	}
 
 	// Success
 	return ValidationResult{
-		Name:   input.Name,
+		Name:   input.source.name,
 		Valid:  true,
 		Errors: []ValidationError{},
-	}, nil
+	}
 }
 
 // createErrorResult creates a result with a single error message
@@ -92,11 +472,26 @@ func createErrorResult(name string, message string) ValidationResult {
 	}
 }
 
+// createParseErrorResult creates a result for data that could not be parsed,
+// keeping the error position when the parser reported one
+func createParseErrorResult(name string, err error) ValidationResult {
+	result := createErrorResult(name, fmt.Sprintf("failed to parse: %v", err))
+	result.ParseFailed = true
+
+	var cueErr errors.Error
+	if errors.As(err, &cueErr) {
+		result.Errors[0].Line = extractLineNumber(cueErr, nil)
+		result.Errors[0].Column = extractColumnNumber(cueErr, nil)
+	}
+
+	return result
+}
+
 // createValidationErrorResult creates a result with extracted validation errors
-func createValidationErrorResult(name string, err error) ValidationResult {
+func createValidationErrorResult(source *sourceContext, err error) ValidationResult {
 	return ValidationResult{
-		Name:   name,
+		Name:   source.name,
 		Valid:  false,
-		Errors: extractValidationErrors(err),
+		Errors: extractValidationErrors(err, source),
 	}
 }
@@ -0,0 +1,210 @@
+package cuebridge
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/token"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLTagHandler converts a YAML node carrying a custom tag (e.g., "!Ref"
+// in CloudFormation templates) into the CUE expression that replaces it.
+// The node includes its tag and any nested nodes.
+type YAMLTagHandler func(node *yaml.Node) (ast.Expr, error)
+
+// yamlTagSite identifies where a tagged value sits in the extracted CUE:
+// the value of the field whose label starts at offset (index < 0),
+// or element index of the list that starts at offset
+type yamlTagSite struct {
+	offset int
+	index  int
+}
+
+// yamlTagResolver blanks out custom YAML tags so that the extractor accepts
+// the data, and records the handler result for each tagged value
+type yamlTagResolver struct {
+	data     []byte
+	blanked  []byte
+	file     *token.File
+	lines    []int
+	handlers map[string]YAMLTagHandler
+	// replacements maps the site of a tagged value to its handler result
+	replacements map[yamlTagSite]ast.Expr
+}
+
+// resolveYAMLTags returns data with custom tags replaced by spaces, keeping
+// all offsets intact, and the expressions that replace the tagged values.
+// A custom tag without a handler is an error naming the tag and line.
+// Syntax errors are left to the extractor.
+func resolveYAMLTags(data []byte, filename string, handlers map[string]YAMLTagHandler) ([]byte, map[yamlTagSite]ast.Expr, error) {
+	file := token.NewFile(filename, -1, len(data))
+	file.SetLinesForContent(data)
+
+	r := &yamlTagResolver{
+		data:         data,
+		blanked:      bytes.Clone(data),
+		file:         file,
+		lines:        lineOffsets(data),
+		handlers:     handlers,
+		replacements: map[yamlTagSite]ast.Expr{},
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return data, nil, nil
+		}
+		if err := r.walk(&doc, nil, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return r.blanked, r.replacements, nil
+}
+
+// walk resolves the custom tags in node and its children. site is where
+// node sits in the extracted CUE, or nil for a document root or map key.
+// Tags nested inside a handled node are blanked but left to its handler.
+func (r *yamlTagResolver) walk(node *yaml.Node, site *yamlTagSite, handled bool) error {
+	if node.Style&yaml.TaggedStyle != 0 && !strings.HasPrefix(node.Tag, "!!") {
+		if start, end, ok := r.tagSpan(node); ok {
+			copy(r.blanked[start:end], bytes.Repeat([]byte(" "), end-start))
+		}
+
+		if !handled {
+			pos := r.file.Pos(r.offset(node), token.NoRelPos)
+			handler, found := r.handlers[node.Tag]
+			if !found {
+				return errors.Newf(pos, "unknown YAML tag %s at line %d", node.Tag, node.Line)
+			}
+			expr, err := handler(node)
+			if err != nil {
+				return errors.Newf(pos, "YAML tag %s at line %d: %v", node.Tag, node.Line, err)
+			}
+			if site == nil {
+				return errors.Newf(pos, "YAML tag %s at line %d: only field values and list elements can be tagged", node.Tag, node.Line)
+			}
+			ast.SetPos(expr, pos)
+			r.replacements[*site] = expr
+			handled = true
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if err := r.walk(key, nil, handled); err != nil {
+				return err
+			}
+			if err := r.walk(value, &yamlTagSite{offset: r.offset(key), index: -1}, handled); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			if err := r.walk(child, &yamlTagSite{offset: r.offset(node), index: i}, handled); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, child := range node.Content {
+			if err := r.walk(child, nil, handled); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// offset returns the byte offset of a node's position. yaml.v3 counts
+// columns in runes, so non-ASCII text before the node is stepped over
+// rune by rune.
+func (r *yamlTagResolver) offset(node *yaml.Node) int {
+	if node.Line < 1 || node.Line > len(r.lines) {
+		return 0
+	}
+	offset := r.lines[node.Line-1]
+	for column := 1; column < node.Column && offset < len(r.data); column++ {
+		_, size := utf8.DecodeRune(r.data[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// tagSpan returns the byte range of a node's tag in the data,
+// skipping an anchor that precedes it
+func (r *yamlTagResolver) tagSpan(node *yaml.Node) (int, int, bool) {
+	i := r.offset(node)
+	if i < len(r.data) && r.data[i] == '&' {
+		i = skipSpace(r.data, skipToken(r.data, i))
+	}
+	if i >= len(r.data) || r.data[i] != '!' {
+		return 0, 0, false
+	}
+	return i, skipToken(r.data, i), true
+}
+
+// replaceTaggedValues replaces the tagged values in the extracted file
+// with the handler results
+func replaceTaggedValues(file *ast.File, replacements map[yamlTagSite]ast.Expr) {
+	ast.Walk(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.Field:
+			site := yamlTagSite{offset: x.Label.Pos().Offset(), index: -1}
+			if replacement, ok := replacements[site]; ok && x.Label.Pos().IsValid() {
+				x.Value = replacement
+				return false
+			}
+		case *ast.ListLit:
+			for i := range x.Elts {
+				site := yamlTagSite{offset: x.Lbrack.Offset(), index: i}
+				if replacement, ok := replacements[site]; ok && x.Lbrack.IsValid() {
+					x.Elts[i] = replacement
+				}
+			}
+		}
+		return true
+	}, nil)
+}
+
+// lineOffsets returns the byte offset at which each line of data starts
+func lineOffsets(data []byte) []int {
+	offsets := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// skipToken returns the offset of the first whitespace at or after i
+func skipToken(data []byte, i int) int {
+	for i < len(data) && !isYAMLSpace(data[i]) {
+		i++
+	}
+	return i
+}
+
+// skipSpace returns the offset of the first non-whitespace at or after i
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && isYAMLSpace(data[i]) {
+		i++
+	}
+	return i
+}
+
+// isYAMLSpace reports whether b separates YAML tokens
+func isYAMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}